@@ -0,0 +1,541 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/thedittmer/rss-reader/internal/export"
+	"github.com/thedittmer/rss-reader/internal/models"
+	"github.com/thedittmer/rss-reader/internal/storage"
+)
+
+// runCommand runs a non-interactive subcommand and returns the exit code
+func runCommand(app *App, args []string) int {
+	switch args[0] {
+	case "refresh":
+		return app.refreshCommand(args[1:])
+	case "list":
+		return app.listCommand(args[1:])
+	case "search":
+		return app.searchCommand(args[1:])
+	case "recommend":
+		return app.recommendCommand(args[1:])
+	case "feeds":
+		return app.feedsCommand(args[1:])
+	case "opml":
+		return app.opmlCommand(args[1:])
+	case "sheets":
+		return app.sheetsCommand(args[1:])
+	case "help", "-h", "--help":
+		printUsage()
+		return 0
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown command: %s\n\n", args[0])
+		printUsage()
+		return 2
+	}
+}
+
+func printUsage() {
+	fmt.Fprintln(os.Stderr, "Usage:")
+	fmt.Fprintln(os.Stderr, "  rss-reader                      Start the interactive reader")
+	fmt.Fprintln(os.Stderr, "  rss-reader refresh [--force]    Fetch feeds that are due (--force: all enabled feeds)")
+	fmt.Fprintln(os.Stderr, "  rss-reader list [--unread] [--feed FEED | --category NAME] [--limit N]")
+	fmt.Fprintln(os.Stderr, "                                  List articles, newest first")
+	fmt.Fprintln(os.Stderr, "  rss-reader search QUERY [--limit N]")
+	fmt.Fprintln(os.Stderr, "                                  Search articles, best match first")
+	fmt.Fprintln(os.Stderr, "  rss-reader recommend [--limit N] List articles matching your interests")
+	fmt.Fprintln(os.Stderr, "  rss-reader feeds list           List subscriptions")
+	fmt.Fprintln(os.Stderr, "  rss-reader feeds add URL [--category NAME] [--title TITLE] [--no-validate]")
+	fmt.Fprintln(os.Stderr, "  rss-reader feeds remove FEED    Remove a feed by URL, number or title")
+	fmt.Fprintln(os.Stderr, "  rss-reader opml import FILE     Add the feeds listed in an OPML file")
+	fmt.Fprintln(os.Stderr, "  rss-reader opml export FILE     Write your feeds to an OPML file")
+	fmt.Fprintln(os.Stderr, "  rss-reader sheets check         Verify the Google Sheets export setup")
+	fmt.Fprintln(os.Stderr, "  rss-reader sheets login         Sign in to Google again (OAuth client credentials)")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "Commands that print data accept --json for machine-readable output. list, search")
+	fmt.Fprintln(os.Stderr, "and recommend also accept --format ndjson for one JSON object per line.")
+}
+
+// parseFlags parses flags that may appear before, between or after the
+// positional arguments, and returns the positional arguments
+func parseFlags(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+func (a *App) refreshCommand(args []string) int {
+	fs := flag.NewFlagSet("refresh", flag.ContinueOnError)
+	force := fs.Bool("force", false, "fetch every enabled feed, even if it is not due")
+	asJSON := fs.Bool("json", false, "print JSON")
+	if _, err := parseFlags(fs, args); err != nil {
+		return 2
+	}
+
+	// Ctrl-C stops the refresh; feeds that finished are still saved
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	results, added, err := a.fetchFeeds(ctx, *force)
+
+	failed := 0
+	for _, result := range results {
+		if result.Status == models.RefreshFailed {
+			failed++
+		}
+	}
+
+	if *asJSON {
+		type feedResult struct {
+			URL        string `json:"url"`
+			Status     string `json:"status"`
+			StatusCode int    `json:"statusCode,omitempty"`
+			Items      int    `json:"items"`
+			DurationMs int64  `json:"durationMs"`
+			Error      string `json:"error,omitempty"`
+		}
+		out := struct {
+			Feeds []feedResult `json:"feeds"`
+			Added int          `json:"added"`
+		}{Feeds: []feedResult{}, Added: added}
+		for _, result := range results {
+			fr := feedResult{
+				URL:        result.URL,
+				Status:     string(result.Status),
+				StatusCode: result.StatusCode,
+				Items:      result.ItemCount,
+				DurationMs: result.Duration.Milliseconds(),
+			}
+			if result.Err != nil {
+				fr.Error = result.Err.Error()
+			}
+			out.Feeds = append(out.Feeds, fr)
+		}
+		if err := writeJSON(out); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing output: %v\n", err)
+			return 1
+		}
+	} else {
+		for _, result := range results {
+			detail := ""
+			switch result.Status {
+			case models.RefreshOK:
+				detail = fmt.Sprintf("%d items", result.ItemCount)
+			case models.RefreshFailed:
+				detail = result.Err.Error()
+			}
+			fmt.Printf("%s\t%s\t%s\n", result.Status, result.URL, detail)
+		}
+		fmt.Printf("%d new articles\n", added)
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to save articles: %v\n", err)
+		return 1
+	}
+	if ctx.Err() != nil {
+		fmt.Fprintln(os.Stderr, "Refresh cancelled")
+		return 1
+	}
+	if failed > 0 {
+		return 1
+	}
+	return 0
+}
+
+func (a *App) listCommand(args []string) int {
+	fs := flag.NewFlagSet("list", flag.ContinueOnError)
+	unread := fs.Bool("unread", false, "only unread articles")
+	feedArg := fs.String("feed", "", "only articles of this feed (URL, number or title)")
+	category := fs.String("category", "", "only articles of feeds in this category")
+	limit := fs.Int("limit", 0, "print at most this many articles (0 for all)")
+	format := addFormatFlags(fs)
+	if _, err := parseFlags(fs, args); err != nil {
+		return 2
+	}
+	if *feedArg != "" && *category != "" {
+		fmt.Fprintln(os.Stderr, "Usage: rss-reader list [--unread] [--feed FEED | --category NAME] [--limit N] [--format plain|json|ndjson]")
+		return 2
+	}
+
+	var items []models.FeedItem
+	switch {
+	case *feedArg != "":
+		feed, ok := a.findFeed(*feedArg)
+		if !ok {
+			fmt.Fprintf(os.Stderr, "No feed matches %q\n", *feedArg)
+			return 1
+		}
+		items = a.feedItems(feed.URL)
+	case *category != "":
+		if !a.setCategory(*category) {
+			fmt.Fprintf(os.Stderr, "No feeds in category %q\n", *category)
+			return 1
+		}
+		items = a.articles()
+	default:
+		items = a.articles()
+	}
+
+	if *unread {
+		items = a.filterUnread(items)
+	}
+	items = newestFirst(items)
+
+	return a.printArticles(scoreless(items), *limit, false, format)
+}
+
+func (a *App) searchCommand(args []string) int {
+	fs := flag.NewFlagSet("search", flag.ContinueOnError)
+	limit := fs.Int("limit", 0, "print at most this many articles (0 for all)")
+	format := addFormatFlags(fs)
+	terms, err := parseFlags(fs, args)
+	if err != nil {
+		return 2
+	}
+
+	query := strings.TrimSpace(strings.Join(terms, " "))
+	if query == "" {
+		fmt.Fprintln(os.Stderr, "Usage: rss-reader search QUERY [--limit N] [--format plain|json|ndjson]")
+		return 2
+	}
+
+	var results []models.ArticleScore
+	for _, result := range a.searchItems(query) {
+		results = append(results, models.ArticleScore{Item: result.Item, Score: result.Score})
+	}
+	return a.printArticles(results, *limit, true, format)
+}
+
+func (a *App) recommendCommand(args []string) int {
+	fs := flag.NewFlagSet("recommend", flag.ContinueOnError)
+	limit := fs.Int("limit", 20, "print at most this many articles (0 for all)")
+	format := addFormatFlags(fs)
+	if _, err := parseFlags(fs, args); err != nil {
+		return 2
+	}
+
+	if len(a.profile.Interests) == 0 {
+		fmt.Fprintln(os.Stderr, "No interests set. Add some interests first!")
+		return 1
+	}
+
+	recommendations := a.sortRecommendations(a.recommendations(), SortByScore)
+	return a.printArticles(recommendations, *limit, true, format)
+}
+
+func (a *App) feedsCommand(args []string) int {
+	if len(args) == 0 {
+		printUsage()
+		return 2
+	}
+
+	switch args[0] {
+	case "list":
+		fs := flag.NewFlagSet("feeds list", flag.ContinueOnError)
+		asJSON := fs.Bool("json", false, "print JSON")
+		if _, err := parseFlags(fs, args[1:]); err != nil {
+			return 2
+		}
+
+		a.mu.Lock()
+		feeds := make([]models.Feed, len(a.feeds))
+		copy(feeds, a.feeds)
+		a.mu.Unlock()
+
+		if *asJSON {
+			if err := writeJSON(feeds); err != nil {
+				fmt.Fprintf(os.Stderr, "Error writing output: %v\n", err)
+				return 1
+			}
+			return 0
+		}
+		for i, feed := range feeds {
+			state := "enabled"
+			if !feed.Enabled {
+				state = "paused"
+			}
+			fmt.Printf("%d\t%s\t%s\t%s\t%s\n", i+1, feed.URL, feed.DisplayTitle(a.metaFor(feed.URL)), feed.Category, state)
+		}
+	case "add":
+		fs := flag.NewFlagSet("feeds add", flag.ContinueOnError)
+		category := fs.String("category", "", "category of the feed")
+		title := fs.String("title", "", "display title of the feed")
+		noValidate := fs.Bool("no-validate", false, "add the feed without fetching it first")
+		positional, err := parseFlags(fs, args[1:])
+		if err != nil {
+			return 2
+		}
+		if len(positional) != 1 {
+			fmt.Fprintln(os.Stderr, "Usage: rss-reader feeds add URL [--category NAME] [--title TITLE] [--no-validate]")
+			return 2
+		}
+
+		feedURL, err := normalizeURL(positional[0])
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		if a.hasFeed(feedURL) {
+			fmt.Fprintf(os.Stderr, "Already subscribed to %s\n", feedURL)
+			return 1
+		}
+		if !*noValidate {
+			if err := a.validateFeed(feedURL); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				return 1
+			}
+		}
+
+		feed := models.NewFeed(feedURL)
+		feed.Title = strings.TrimSpace(*title)
+		feed.Category = normalizeCategory(*category)
+		if err := a.addFeed(feed); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to save feeds: %v\n", err)
+			return 1
+		}
+		fmt.Printf("Added %s\n", feedURL)
+	case "remove":
+		if len(args) != 2 {
+			fmt.Fprintln(os.Stderr, "Usage: rss-reader feeds remove FEED")
+			return 2
+		}
+
+		feed, ok := a.findFeed(args[1])
+		if !ok {
+			fmt.Fprintf(os.Stderr, "No feed matches %q\n", args[1])
+			return 1
+		}
+		if err := a.removeFeed(feed.URL); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to save feeds: %v\n", err)
+			return 1
+		}
+		fmt.Printf("Removed %s\n", feed.URL)
+	default:
+		printUsage()
+		return 2
+	}
+	return 0
+}
+
+func (a *App) opmlCommand(args []string) int {
+	if len(args) != 2 {
+		printUsage()
+		return 2
+	}
+
+	switch args[0] {
+	case "import":
+		added, skipped, err := a.importOPML(args[1])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Import failed: %v\n", err)
+			return 1
+		}
+		fmt.Printf("Imported %d feeds (%d duplicates or invalid skipped)\n", added, skipped)
+	case "export":
+		count, err := a.exportOPML(args[1])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Export failed: %v\n", err)
+			return 1
+		}
+		fmt.Printf("Exported %d feeds to %s\n", count, args[1])
+	default:
+		printUsage()
+		return 2
+	}
+	return 0
+}
+
+func (a *App) sheetsCommand(args []string) int {
+	if len(args) != 1 || args[0] != "check" && args[0] != "login" {
+		printUsage()
+		return 2
+	}
+
+	sheetsConfig, err := a.store.SheetsConfig(a.config.Sheets.Folder)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid sheets.folder: %v\n", err)
+		return 1
+	}
+	sheetsConfig.OpenAuthURL = func(authURL string) {
+		fmt.Fprintf(os.Stderr, "Sign in to Google in your browser. If the page does not open, visit:\n%s\n", authURL)
+		openInBrowser(authURL)
+	}
+
+	if args[0] == "login" {
+		if err := a.store.LoginSheets(sheetsConfig); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		fmt.Printf("Signed in; the token is saved in %s\n", sheetsConfig.TokenFile)
+		return 0
+	}
+
+	if err := a.store.CheckSheetsSetup(sheetsConfig); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	if sheetsConfig.FolderID == storage.RootFolder {
+		fmt.Println("Google Sheets export is set up; spreadsheets go to My Drive")
+	} else {
+		fmt.Printf("Google Sheets export is set up; spreadsheets go to folder %s\n", sheetsConfig.FolderID)
+	}
+	return 0
+}
+
+// findFeed looks up a subscribed feed by URL, list number or display title
+func (a *App) findFeed(arg string) (models.Feed, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if n, err := strconv.Atoi(arg); err == nil {
+		if n >= 1 && n <= len(a.feeds) {
+			return a.feeds[n-1], true
+		}
+		return models.Feed{}, false
+	}
+
+	canonical := models.CanonicalURL(arg)
+	for _, feed := range a.feeds {
+		if models.CanonicalURL(feed.URL) == canonical {
+			return feed, true
+		}
+	}
+	for _, feed := range a.feeds {
+		if strings.EqualFold(feed.DisplayTitle(a.feedMeta[feed.URL]), arg) {
+			return feed, true
+		}
+	}
+	return models.Feed{}, false
+}
+
+// setCategory limits articles() to a category and reports whether any feed
+// is in it
+func (a *App) setCategory(name string) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for _, category := range models.Categories(a.feeds) {
+		if strings.EqualFold(category, name) {
+			a.category = category
+			return true
+		}
+	}
+	return false
+}
+
+// outputFormat holds the --format and --json flags of commands that print
+// articles
+type outputFormat struct {
+	format string
+	json   bool
+}
+
+func addFormatFlags(fs *flag.FlagSet) *outputFormat {
+	o := &outputFormat{}
+	fs.StringVar(&o.format, "format", "plain", "output format: plain, json or ndjson")
+	fs.BoolVar(&o.json, "json", false, "shorthand for --format json")
+	return o
+}
+
+func (o *outputFormat) resolve() (string, error) {
+	if o.json {
+		return string(export.FormatJSON), nil
+	}
+	switch o.format {
+	case "plain", string(export.FormatJSON), string(export.FormatNDJSON):
+		return o.format, nil
+	default:
+		return "", fmt.Errorf("unknown format %q (valid formats: plain, json, ndjson)", o.format)
+	}
+}
+
+// printArticles prints articles one per line as tab-separated fields, or in
+// one of the machine-readable formats
+func (a *App) printArticles(articles []models.ArticleScore, limit int, withScore bool, output *outputFormat) int {
+	format, err := output.resolve()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+
+	if limit > 0 && len(articles) > limit {
+		articles = articles[:limit]
+	}
+
+	if format != "plain" {
+		out := export.NewArticles(articles, a.profile.IsRead, withScore)
+		if err := export.Write(os.Stdout, export.Format(format), out); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing output: %v\n", err)
+			return 1
+		}
+		return 0
+	}
+
+	for _, article := range articles {
+		item := article.Item
+		state := "unread"
+		if a.profile.IsRead(item) {
+			state = "read"
+		}
+		fields := []string{
+			item.Published.Format(time.RFC3339),
+			state,
+			singleLine(item.SourceLabel()),
+			singleLine(item.Title),
+			item.Link,
+		}
+		if withScore {
+			fields = append([]string{fmt.Sprintf("%.1f", article.Score)}, fields...)
+		}
+		fmt.Println(strings.Join(fields, "\t"))
+	}
+	return 0
+}
+
+func writeJSON(v interface{}) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// singleLine collapses whitespace so a value fits in one tab-separated field
+func singleLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func newestFirst(items []models.FeedItem) []models.FeedItem {
+	sorted := make([]models.FeedItem, len(items))
+	copy(sorted, items)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Published.After(sorted[j].Published)
+	})
+	return sorted
+}
+
+func scoreless(items []models.FeedItem) []models.ArticleScore {
+	articles := make([]models.ArticleScore, len(items))
+	for i, item := range items {
+		articles[i] = models.ArticleScore{Item: item}
+	}
+	return articles
+}
@@ -2,32 +2,186 @@ package config
 
 import (
 	"bufio"
+	"encoding/json"
+	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 )
 
 type Config struct {
 	Theme struct {
-		Dark        bool
-		AccentColor string
-	}
+		// One of dark, light, high-contrast or monochrome. When empty the
+		// theme follows Dark.
+		Name        string `json:"name"`
+		Dark        bool   `json:"dark"`
+		AccentColor string `json:"accentColor"`
+	} `json:"theme"`
 	Behavior struct {
-		AutoRefreshInterval time.Duration
-		MaxArticlesPerFeed  int
-		DefaultPageSize     int
-	}
+		AutoRefreshInterval Duration `json:"autoRefreshInterval"`
+		MaxArticlesPerFeed  int      `json:"maxArticlesPerFeed"`
+		DefaultPageSize     int      `json:"defaultPageSize"`
+
+		// Feeds fetched at once, in total and from a single host, and how
+		// long to wait for each feed
+		FetchWorkers int      `json:"fetchWorkers"`
+		FetchPerHost int      `json:"fetchPerHost"`
+		FetchTimeout Duration `json:"fetchTimeout"`
+	} `json:"behavior"`
 	Display struct {
-		CompactView    bool
-		ShowReadStatus bool
-		DateFormat     string
-	}
+		CompactView    bool   `json:"compactView"`
+		ShowReadStatus bool   `json:"showReadStatus"`
+		DateFormat     string `json:"dateFormat"`
+	} `json:"display"`
 	Keyboard struct {
 		NextPage    string `json:"nextPage"`
 		PrevPage    string `json:"prevPage"`
 		OpenArticle string `json:"openArticle"`
 		Back        string `json:"back"`
+
+		// Bindings for any other action, keyed by action name (e.g. "toggleRead")
+		Custom map[string]string `json:"custom,omitempty"`
+	} `json:"keyboard"`
+	Sheets struct {
+		// Google Drive folder that receives exported spreadsheets, as a
+		// folder ID or folder URL. Empty or "root" uses My Drive.
+		Folder string `json:"folder"`
+
+		// Write each day's exports to a tab named after the date
+		DatedTabs bool `json:"datedTabs"`
+	} `json:"sheets"`
+}
+
+// Duration is a time.Duration stored as a string such as "30m" in JSON
+type Duration time.Duration
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("duration must be a string such as \"30m\": %w", err)
+	}
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+const (
+	maxPageSize     = 100
+	maxFetchWorkers = 64
+)
+
+// ThemeName returns the configured theme, falling back to dark or light
+func (c *Config) ThemeName() string {
+	if c.Theme.Name != "" {
+		return c.Theme.Name
 	}
+	if c.Theme.Dark {
+		return "dark"
+	}
+	return "light"
+}
+
+// Default returns the configuration used when no config file exists
+func Default() *Config {
+	cfg := &Config{}
+	cfg.Theme.Dark = true
+	cfg.Behavior.AutoRefreshInterval = 0
+	cfg.Behavior.MaxArticlesPerFeed = 0
+	cfg.Behavior.DefaultPageSize = 10
+	cfg.Behavior.FetchWorkers = 8
+	cfg.Behavior.FetchPerHost = 2
+	cfg.Behavior.FetchTimeout = Duration(20 * time.Second)
+	cfg.Display.CompactView = false
+	cfg.Display.ShowReadStatus = true
+	cfg.Display.DateFormat = "2006-01-02"
+	cfg.Keyboard.NextPage = "n"
+	cfg.Keyboard.PrevPage = "p"
+	cfg.Keyboard.OpenArticle = "o"
+	cfg.Keyboard.Back = "b"
+	return cfg
+}
+
+// Validate reports the first invalid setting in the configuration
+func (c *Config) Validate() error {
+	if c.Behavior.AutoRefreshInterval != 0 && time.Duration(c.Behavior.AutoRefreshInterval) < time.Minute {
+		return fmt.Errorf("behavior.autoRefreshInterval must be 0 (disabled) or at least 1m")
+	}
+	if c.Behavior.MaxArticlesPerFeed < 0 {
+		return fmt.Errorf("behavior.maxArticlesPerFeed must be 0 (unlimited) or positive")
+	}
+	if c.Behavior.DefaultPageSize < 1 || c.Behavior.DefaultPageSize > maxPageSize {
+		return fmt.Errorf("behavior.defaultPageSize must be between 1 and %d", maxPageSize)
+	}
+	if c.Behavior.FetchWorkers < 1 || c.Behavior.FetchWorkers > maxFetchWorkers {
+		return fmt.Errorf("behavior.fetchWorkers must be between 1 and %d", maxFetchWorkers)
+	}
+	if c.Behavior.FetchPerHost < 1 || c.Behavior.FetchPerHost > c.Behavior.FetchWorkers {
+		return fmt.Errorf("behavior.fetchPerHost must be between 1 and behavior.fetchWorkers")
+	}
+	if time.Duration(c.Behavior.FetchTimeout) < time.Second {
+		return fmt.Errorf("behavior.fetchTimeout must be at least 1s")
+	}
+
+	// A layout without any reference fields formats every time the same way
+	sample := time.Date(2009, time.November, 10, 23, 4, 5, 0, time.UTC)
+	if c.Display.DateFormat == "" || sample.Format(c.Display.DateFormat) == c.Display.DateFormat {
+		return fmt.Errorf("display.dateFormat %q is not a Go time layout (e.g. \"2006-01-02\")", c.Display.DateFormat)
+	}
+
+	if c.Theme.AccentColor != "" && !isHexColor(c.Theme.AccentColor) {
+		return fmt.Errorf("theme.accentColor %q must be a hex color such as \"#2DA44E\"", c.Theme.AccentColor)
+	}
+
+	keys := map[string]string{
+		"keyboard.nextPage":    c.Keyboard.NextPage,
+		"keyboard.prevPage":    c.Keyboard.PrevPage,
+		"keyboard.openArticle": c.Keyboard.OpenArticle,
+		"keyboard.back":        c.Keyboard.Back,
+	}
+	for name, key := range keys {
+		if len(key) != 1 {
+			return fmt.Errorf("%s must be a single character, got %q", name, key)
+		}
+	}
+
+	return nil
+}
+
+func isHexColor(s string) bool {
+	if len(s) != 4 && len(s) != 7 || s[0] != '#' {
+		return false
+	}
+	for _, r := range s[1:] {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", r) {
+			return false
+		}
+	}
+	return true
+}
+
+// Dir returns the directory holding the application's data files
+func Dir() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, ".rss-reader"), nil
+}
+
+func configPath() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "config.json"), nil
 }
 
 func LoadFeedsFromFile(filename string) ([]string, error) {
@@ -50,10 +204,66 @@ func LoadFeedsFromFile(filename string) ([]string, error) {
 	return feeds, scanner.Err()
 }
 
+// LoadConfig reads config.json from the data directory. Settings missing from
+// the file keep their defaults, and a default file is written if none exists.
 func LoadConfig() (*Config, error) {
-	// Implementation
+	cfg := Default()
+
+	path, err := configPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			if err := SaveConfig(cfg); err != nil {
+				return nil, fmt.Errorf("error creating default config: %w", err)
+			}
+			return cfg, nil
+		}
+		return nil, fmt.Errorf("error reading config: %w", err)
+	}
+
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("error parsing config: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config %s: %w", path, err)
+	}
+
+	return cfg, nil
 }
 
 func SaveConfig(cfg *Config) error {
-	// Implementation
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+
+	path, err := configPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	tempPath := path + ".tmp"
+
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshaling config: %w", err)
+	}
+
+	// Write to temporary file first
+	if err := os.WriteFile(tempPath, data, 0644); err != nil {
+		return fmt.Errorf("error writing temporary config: %w", err)
+	}
+
+	// Rename temporary file to actual file (atomic operation)
+	if err := os.Rename(tempPath, path); err != nil {
+		return fmt.Errorf("error saving config: %w", err)
+	}
+
+	return nil
 }
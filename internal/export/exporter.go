@@ -0,0 +1,21 @@
+package export
+
+import (
+	"github.com/thedittmer/rss-reader/internal/models"
+)
+
+// Exporter writes recommended articles to a destination such as a file or
+// a spreadsheet
+type Exporter interface {
+	// Name is shown in the export picker
+	Name() string
+	Export(articles []models.ArticleScore) (Result, error)
+}
+
+// Result describes where an export was written
+type Result struct {
+	Location string // File path or spreadsheet URL, shown to the user
+	URL      string // Opened in the browser
+	Count    int    // Articles written
+	Skipped  int    // Articles left out because they were exported before
+}
@@ -0,0 +1,163 @@
+package export
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"html/template"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/thedittmer/rss-reader/internal/models"
+)
+
+// fileExporter writes articles to a new timestamped file in a directory
+type fileExporter struct {
+	name  string
+	ext   string
+	dir   string
+	write func(w io.Writer, articles []models.ArticleScore, exported time.Time) error
+}
+
+// NewCSVExporter returns an exporter writing CSV files to dir
+func NewCSVExporter(dir string) Exporter {
+	return &fileExporter{name: "CSV file", ext: ".csv", dir: dir, write: writeCSV}
+}
+
+// NewMarkdownExporter returns an exporter writing Markdown files to dir
+func NewMarkdownExporter(dir string) Exporter {
+	return &fileExporter{name: "Markdown file", ext: ".md", dir: dir, write: writeMarkdown}
+}
+
+// NewHTMLExporter returns an exporter writing standalone HTML pages to dir
+func NewHTMLExporter(dir string) Exporter {
+	return &fileExporter{name: "HTML page", ext: ".html", dir: dir, write: writeHTML}
+}
+
+func (e *fileExporter) Name() string {
+	return e.name
+}
+
+func (e *fileExporter) Export(articles []models.ArticleScore) (Result, error) {
+	if err := os.MkdirAll(e.dir, 0755); err != nil {
+		return Result{}, fmt.Errorf("error creating export directory: %w", err)
+	}
+
+	now := time.Now()
+	path := filepath.Join(e.dir, "recommendations-"+now.Format("2006-01-02-15-04-05")+e.ext)
+	tempPath := path + ".tmp"
+
+	f, err := os.Create(tempPath)
+	if err != nil {
+		return Result{}, fmt.Errorf("error creating export file: %w", err)
+	}
+	if err := e.write(f, articles, now); err != nil {
+		f.Close()
+		os.Remove(tempPath)
+		return Result{}, fmt.Errorf("error writing export file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(tempPath)
+		return Result{}, fmt.Errorf("error writing export file: %w", err)
+	}
+	if err := os.Rename(tempPath, path); err != nil {
+		return Result{}, fmt.Errorf("error saving export file: %w", err)
+	}
+
+	fileURL := (&url.URL{Scheme: "file", Path: filepath.ToSlash(path)}).String()
+	return Result{Location: path, URL: fileURL, Count: len(articles)}, nil
+}
+
+// writeCSV uses the same columns as the Google Sheets export
+func writeCSV(w io.Writer, articles []models.ArticleScore, exported time.Time) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"Title", "Link", "Source", "Published Date", "Score", "Exported Date"}); err != nil {
+		return err
+	}
+	for _, article := range articles {
+		record := []string{
+			article.Item.Title,
+			article.Item.Link,
+			article.Item.FeedSource,
+			article.Item.Published.Format("2006-01-02 15:04:05"),
+			fmt.Sprintf("%.2f", article.Score),
+			exported.Format("2006-01-02 15:04:05"),
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// markdownEscaper escapes the characters that would end a link text early
+// or start unintended formatting
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, `[`, `\[`, `]`, `\]`, `*`, `\*`, `_`, `\_`, "`", "\\`",
+	`<`, `\<`,
+)
+
+// markdownLinkEscaper escapes the characters that would end an angle-bracket
+// link destination early
+var markdownLinkEscaper = strings.NewReplacer(`\`, `\\`, `<`, `\<`, `>`, `\>`)
+
+// writeMarkdown buffers its output; write errors surface when it is flushed
+func writeMarkdown(w io.Writer, articles []models.ArticleScore, exported time.Time) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "# Recommended Articles\n\nExported %s\n\n", exported.Format("January 2, 2006 15:04"))
+	for i, article := range articles {
+		item := article.Item
+		title := markdownEscaper.Replace(strings.TrimSpace(item.Title))
+		if item.Link != "" {
+			// Angle brackets keep spaces and parentheses in the URL intact
+			title = fmt.Sprintf("[%s](<%s>)", title, markdownLinkEscaper.Replace(item.Link))
+		}
+		fmt.Fprintf(bw, "%d. %s  \n", i+1, title)
+		fmt.Fprintf(bw, "   %s · %s · score %.2f\n",
+			markdownEscaper.Replace(item.FeedSource),
+			item.Published.Format("2006-01-02"),
+			article.Score)
+	}
+	return bw.Flush()
+}
+
+var htmlTemplate = template.Must(template.New("export").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>Recommended Articles</title>
+<style>
+body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", sans-serif; max-width: 48rem; margin: 2rem auto; padding: 0 1rem; color: #222; }
+h1 { color: #7D56F4; }
+ol { padding-left: 1.5rem; }
+li { margin-bottom: 1rem; }
+a { color: #1a5fb4; text-decoration: none; font-weight: 600; }
+a:hover { text-decoration: underline; }
+.meta { color: #666; font-size: 0.9rem; }
+</style>
+</head>
+<body>
+<h1>Recommended Articles</h1>
+<p class="meta">Exported {{.Exported.Format "January 2, 2006 15:04"}}</p>
+<ol>
+{{- range .Articles}}
+<li>{{if .Item.Link}}<a href="{{.Item.Link}}">{{.Item.Title}}</a>{{else}}{{.Item.Title}}{{end}}<br>
+<span class="meta">{{.Item.FeedSource}} · {{.Item.Published.Format "2006-01-02"}} · score {{printf "%.2f" .Score}}</span></li>
+{{- end}}
+</ol>
+</body>
+</html>
+`))
+
+func writeHTML(w io.Writer, articles []models.ArticleScore, exported time.Time) error {
+	return htmlTemplate.Execute(w, struct {
+		Exported time.Time
+		Articles []models.ArticleScore
+	}{exported, articles})
+}
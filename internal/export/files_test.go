@@ -0,0 +1,131 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thedittmer/rss-reader/internal/models"
+)
+
+var exportTime = time.Date(2024, time.March, 4, 9, 30, 0, 0, time.UTC)
+
+func scored(title, link, source string) models.ArticleScore {
+	return models.ArticleScore{
+		Item: models.FeedItem{
+			Title:      title,
+			Link:       link,
+			FeedSource: source,
+			Published:  time.Date(2024, time.March, 3, 16, 23, 45, 0, time.UTC),
+		},
+		Score: 1.5,
+	}
+}
+
+func TestWriteCSVQuoting(t *testing.T) {
+	articles := []models.ArticleScore{
+		scored(`Say "hello", world`, "https://example.com/a?x=1,2", "Line\nbreak"),
+	}
+
+	var buf bytes.Buffer
+	if err := writeCSV(&buf, articles, exportTime); err != nil {
+		t.Fatalf("writeCSV: %v", err)
+	}
+	if !strings.Contains(buf.String(), `"Say ""hello"", world"`) {
+		t.Errorf("title not quoted:\n%s", buf.String())
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("output is not valid CSV: %v", err)
+	}
+	want := [][]string{
+		{"Title", "Link", "Source", "Published Date", "Score", "Exported Date"},
+		{`Say "hello", world`, "https://example.com/a?x=1,2", "Line\nbreak", "2024-03-03 16:23:45", "1.50", "2024-03-04 09:30:00"},
+	}
+	if !reflect.DeepEqual(records, want) {
+		t.Errorf("records = %q, want %q", records, want)
+	}
+}
+
+func TestWriteMarkdownEscaping(t *testing.T) {
+	tests := []struct {
+		name    string
+		article models.ArticleScore
+		want    string
+	}{
+		{
+			"brackets in title",
+			scored("[Video] Go *fast* ]", "https://example.com/1", "Blog"),
+			`1. [\[Video\] Go \*fast\* \]](<https://example.com/1>)`,
+		},
+		{
+			"angle brackets in link",
+			scored("Post", "https://example.com/a>b<c", "Blog"),
+			`1. [Post](<https://example.com/a\>b\<c>)`,
+		},
+		{
+			"spaces and parentheses in link",
+			scored("Post", "https://example.com/a (b)", "Blog"),
+			`1. [Post](<https://example.com/a (b)>)`,
+		},
+		{
+			"no link",
+			scored("Plain_title", "", "Blog"),
+			`1. Plain\_title`,
+		},
+		{
+			"source escaped",
+			scored("Post", "", "my_blog"),
+			`   my\_blog · 2024-03-03 · score 1.50`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := writeMarkdown(&buf, []models.ArticleScore{tt.article}, exportTime); err != nil {
+				t.Fatalf("writeMarkdown: %v", err)
+			}
+			// Title lines end in a Markdown line break
+			for _, line := range strings.Split(buf.String(), "\n") {
+				if strings.TrimSuffix(line, "  ") == tt.want {
+					return
+				}
+			}
+			t.Errorf("output lacks line %q:\n%s", tt.want, buf.String())
+		})
+	}
+}
+
+func TestWriteHTMLEscaping(t *testing.T) {
+	articles := []models.ArticleScore{
+		scored(`<script>alert("x")</script> & more`, `javascript:alert(1)`, "<b>Blog</b>"),
+		scored("Safe", `https://example.com/?a=1&b="2"`, "Blog"),
+	}
+
+	var buf bytes.Buffer
+	if err := writeHTML(&buf, articles, exportTime); err != nil {
+		t.Fatalf("writeHTML: %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		`&lt;script&gt;alert(&#34;x&#34;)&lt;/script&gt; &amp; more`,
+		`&lt;b&gt;Blog&lt;/b&gt;`,
+		`href="#ZgotmplZ"`,
+		`href="https://example.com/?a=1&amp;b=%222%22"`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output lacks %q:\n%s", want, out)
+		}
+	}
+	for _, unwanted := range []string{"<script>", "<b>Blog", "javascript:"} {
+		if strings.Contains(out, unwanted) {
+			t.Errorf("output contains unescaped %q", unwanted)
+		}
+	}
+}
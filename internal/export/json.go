@@ -0,0 +1,90 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/thedittmer/rss-reader/internal/models"
+)
+
+// Article is the machine-readable form of an article. The JSON field names
+// are relied on by scripts and must not change; new fields may be added.
+type Article struct {
+	ID            string    `json:"id"`
+	Title         string    `json:"title"`
+	Link          string    `json:"link"`
+	Source        string    `json:"source"`
+	Sources       []string  `json:"sources,omitempty"` // Every feed a merged article appeared in
+	FeedURL       string    `json:"feedUrl"`
+	Author        string    `json:"author,omitempty"`
+	Categories    []string  `json:"categories,omitempty"`
+	Description   string    `json:"description,omitempty"`
+	Published     time.Time `json:"published"`
+	DateEstimated bool      `json:"dateEstimated"`
+	Read          bool      `json:"read"`
+	Score         *float64  `json:"score,omitempty"` // Only set for recommendations and search results
+}
+
+// Format is a machine-readable output format
+type Format string
+
+const (
+	FormatJSON   Format = "json"   // One indented JSON array
+	FormatNDJSON Format = "ndjson" // One compact JSON object per line
+)
+
+// NewArticles converts scored articles. isRead reports the read state of an
+// item; scores are only included when withScore is set.
+func NewArticles(articles []models.ArticleScore, isRead func(models.FeedItem) bool, withScore bool) []Article {
+	out := make([]Article, 0, len(articles))
+	for _, article := range articles {
+		item := article.Item
+		a := Article{
+			ID:            item.ID,
+			Title:         item.Title,
+			Link:          item.Link,
+			Source:        item.FeedSource,
+			FeedURL:       item.FeedURL,
+			Author:        item.Author,
+			Categories:    item.Categories,
+			Description:   item.Description,
+			Published:     item.Published,
+			DateEstimated: item.DateEstimated,
+			Read:          isRead(item),
+		}
+		if len(item.Sources) > 1 {
+			a.Sources = item.Sources
+		}
+		if withScore {
+			score := article.Score
+			a.Score = &score
+		}
+		out = append(out, a)
+	}
+	return out
+}
+
+// Write encodes articles in the given format
+func Write(w io.Writer, format Format, articles []Article) error {
+	switch format {
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if articles == nil {
+			articles = []Article{}
+		}
+		return enc.Encode(articles)
+	case FormatNDJSON:
+		enc := json.NewEncoder(w)
+		for _, article := range articles {
+			if err := enc.Encode(article); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown output format %q", format)
+	}
+}
@@ -0,0 +1,119 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thedittmer/rss-reader/internal/models"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+func testArticles() []Article {
+	published := time.Date(2024, time.March, 3, 16, 23, 45, 0, time.UTC)
+	articles := []models.ArticleScore{
+		{
+			Item: models.FeedItem{
+				ID:          "sha1:1",
+				Title:       "Go 1.22 is released",
+				Link:        "https://go.dev/blog/go1.22",
+				FeedSource:  "The Go Blog",
+				FeedURL:     "https://go.dev/blog/feed.atom",
+				Author:      "Go Team",
+				Categories:  []string{"release"},
+				Description: "Loop variables & <range> over ints",
+				Published:   published,
+				Sources:     []string{"The Go Blog", "Hacker News"},
+			},
+			Score: 2.5,
+		},
+		{
+			Item: models.FeedItem{
+				ID:            "sha1:2",
+				Title:         "Minimal item",
+				Link:          "https://example.com/2",
+				FeedSource:    "Example",
+				FeedURL:       "https://example.com/rss",
+				Published:     published.Add(-time.Hour),
+				DateEstimated: true,
+				Sources:       []string{"Example"},
+			},
+			Score: 0.5,
+		},
+	}
+	isRead := func(item models.FeedItem) bool { return item.ID == "sha1:2" }
+	return NewArticles(articles, isRead, true)
+}
+
+// checkGolden compares got with testdata/name, rewriting it with -update
+func checkGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(path, got, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("output differs from %s:\n%s\nwant:\n%s", path, got, want)
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatJSON, testArticles()); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	checkGolden(t, "articles.json", buf.Bytes())
+}
+
+func TestWriteJSONEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatJSON, nil); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if got := buf.String(); got != "[]\n" {
+		t.Errorf("output = %q, want an empty array", got)
+	}
+}
+
+func TestWriteNDJSON(t *testing.T) {
+	articles := testArticles()
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatNDJSON, articles); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	checkGolden(t, "articles.ndjson", buf.Bytes())
+
+	// Every line holds one complete article
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != len(articles) {
+		t.Fatalf("got %d lines, want %d", len(lines), len(articles))
+	}
+	for i, line := range lines {
+		var article Article
+		if err := json.Unmarshal([]byte(line), &article); err != nil {
+			t.Errorf("line %d is not a JSON object: %v", i+1, err)
+			continue
+		}
+		if article.ID != articles[i].ID {
+			t.Errorf("line %d holds %q, want %q", i+1, article.ID, articles[i].ID)
+		}
+	}
+}
+
+func TestWriteUnknownFormat(t *testing.T) {
+	if err := Write(&bytes.Buffer{}, Format("xml"), nil); err == nil {
+		t.Error("Write succeeded with an unknown format, want an error")
+	}
+}
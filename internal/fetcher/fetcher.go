@@ -0,0 +1,106 @@
+package fetcher
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+	"github.com/thedittmer/rss-reader/internal/models"
+)
+
+// Fetcher downloads feeds using conditional requests when metadata is available
+type Fetcher struct {
+	Client    *http.Client
+	UserAgent string
+	Options   Options
+}
+
+// Result is the outcome of fetching a single feed
+type Result struct {
+	Feed        *gofeed.Feed // nil when the feed was not modified
+	NotModified bool
+	Meta        models.FeedMeta
+}
+
+// New returns a fetcher scheduling requests with options; zero fields use
+// the defaults
+func New(options Options) *Fetcher {
+	return &Fetcher{
+		Client:    &http.Client{},
+		UserAgent: "rss-reader/1.0",
+		Options:   options.withDefaults(),
+	}
+}
+
+// Fetch retrieves the feed at url. The ETag and Last-Modified values from meta
+// are sent as If-None-Match and If-Modified-Since; a 304 response is reported
+// via Result.NotModified so the caller can keep its cached items.
+func (f *Fetcher) Fetch(ctx context.Context, url string, meta models.FeedMeta) (*Result, error) {
+	if f.Options.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, f.Options.Timeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid feed request: %w", err)
+	}
+	if f.UserAgent != "" {
+		req.Header.Set("User-Agent", f.UserAgent)
+	}
+	if meta.ETag != "" {
+		req.Header.Set("If-None-Match", meta.ETag)
+	}
+	if meta.LastModified != "" {
+		req.Header.Set("If-Modified-Since", meta.LastModified)
+	}
+
+	client := f.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	meta.URL = url
+	meta.LastFetched = time.Now()
+
+	resp, err := client.Do(req)
+	if err != nil {
+		meta.LastStatus = 0
+		return &Result{Meta: meta}, fmt.Errorf("could not fetch feed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	meta.LastStatus = resp.StatusCode
+
+	switch resp.StatusCode {
+	case http.StatusNotModified:
+		return &Result{NotModified: true, Meta: meta}, nil
+	case http.StatusOK:
+	default:
+		return &Result{Meta: meta}, fmt.Errorf("feed returned status code %d", resp.StatusCode)
+	}
+
+	feed, err := gofeed.NewParser().Parse(resp.Body)
+	if err != nil {
+		return &Result{Meta: meta}, fmt.Errorf("could not parse feed: %w", err)
+	}
+
+	// Only remember validators from successful responses
+	meta.ETag = resp.Header.Get("ETag")
+	meta.LastModified = resp.Header.Get("Last-Modified")
+
+	meta.Title = feed.Title
+	meta.SiteLink = feed.Link
+	meta.Description = feed.Description
+	meta.Updated = time.Time{}
+	if feed.UpdatedParsed != nil {
+		meta.Updated = *feed.UpdatedParsed
+	} else if feed.PublishedParsed != nil {
+		meta.Updated = *feed.PublishedParsed
+	}
+
+	return &Result{Feed: feed, Meta: meta}, nil
+}
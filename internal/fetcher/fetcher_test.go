@@ -0,0 +1,152 @@
+package fetcher
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/thedittmer/rss-reader/internal/models"
+)
+
+const testFeed = `<?xml version="1.0"?>
+<rss version="2.0"><channel>
+<title>Test Feed</title>
+<link>https://example.com/</link>
+<item><title>First</title><link>https://example.com/1</link><guid>1</guid></item>
+</channel></rss>`
+
+const (
+	testETag         = `"v1"`
+	testLastModified = "Mon, 02 Jan 2006 15:04:05 GMT"
+)
+
+// conditionalServer serves testFeed, answering 304 when the request carries
+// the current validators, and records the conditional headers it receives
+type conditionalServer struct {
+	mu              sync.Mutex
+	ifNoneMatch     []string
+	ifModifiedSince []string
+}
+
+func (s *conditionalServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.ifNoneMatch = append(s.ifNoneMatch, r.Header.Get("If-None-Match"))
+	s.ifModifiedSince = append(s.ifModifiedSince, r.Header.Get("If-Modified-Since"))
+	s.mu.Unlock()
+
+	if r.Header.Get("If-None-Match") == testETag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("ETag", testETag)
+	w.Header().Set("Last-Modified", testLastModified)
+	fmt.Fprint(w, testFeed)
+}
+
+func TestFetchStoresValidators(t *testing.T) {
+	server := httptest.NewServer(&conditionalServer{})
+	defer server.Close()
+
+	result, err := New(DefaultOptions()).Fetch(context.Background(), server.URL, models.FeedMeta{})
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+
+	if result.NotModified || result.Feed == nil || len(result.Feed.Items) != 1 {
+		t.Fatalf("result = %+v, want the parsed feed", result)
+	}
+	if result.Meta.ETag != testETag || result.Meta.LastModified != testLastModified {
+		t.Errorf("validators = %q, %q; want %q, %q",
+			result.Meta.ETag, result.Meta.LastModified, testETag, testLastModified)
+	}
+	if result.Meta.LastStatus != http.StatusOK || result.Meta.Title != "Test Feed" {
+		t.Errorf("meta = %+v, want status 200 and the feed title", result.Meta)
+	}
+}
+
+func TestFetchSendsConditionalHeaders(t *testing.T) {
+	handler := &conditionalServer{}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	f := New(DefaultOptions())
+	first, err := f.Fetch(context.Background(), server.URL, models.FeedMeta{})
+	if err != nil {
+		t.Fatalf("first Fetch: %v", err)
+	}
+	if _, err := f.Fetch(context.Background(), server.URL, first.Meta); err != nil {
+		t.Fatalf("second Fetch: %v", err)
+	}
+
+	if handler.ifNoneMatch[0] != "" || handler.ifModifiedSince[0] != "" {
+		t.Errorf("first request sent validators %q, %q; want none",
+			handler.ifNoneMatch[0], handler.ifModifiedSince[0])
+	}
+	if handler.ifNoneMatch[1] != testETag || handler.ifModifiedSince[1] != testLastModified {
+		t.Errorf("second request sent %q, %q; want %q, %q",
+			handler.ifNoneMatch[1], handler.ifModifiedSince[1], testETag, testLastModified)
+	}
+}
+
+func TestFetchNotModifiedKeepsCache(t *testing.T) {
+	server := httptest.NewServer(&conditionalServer{})
+	defer server.Close()
+
+	meta := models.FeedMeta{ETag: testETag, LastModified: testLastModified, Title: "Test Feed"}
+	result, err := New(DefaultOptions()).Fetch(context.Background(), server.URL, meta)
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+
+	// No feed is returned, so the caller keeps its cached items
+	if !result.NotModified || result.Feed != nil {
+		t.Fatalf("result = %+v, want not modified without a feed", result)
+	}
+	if result.Meta.LastStatus != http.StatusNotModified {
+		t.Errorf("status = %d, want 304", result.Meta.LastStatus)
+	}
+	if result.Meta.ETag != testETag || result.Meta.LastModified != testLastModified || result.Meta.Title != "Test Feed" {
+		t.Errorf("meta = %+v, want the stored validators and title kept", result.Meta)
+	}
+}
+
+func TestFetchErrors(t *testing.T) {
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "gone", http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closedURL := closed.URL
+	closed.Close()
+
+	tests := []struct {
+		name       string
+		url        string
+		wantStatus int
+	}{
+		{"non-2xx status", failing.URL, http.StatusInternalServerError},
+		{"network error", closedURL, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			meta := models.FeedMeta{ETag: testETag}
+			result, err := New(DefaultOptions()).Fetch(context.Background(), tt.url, meta)
+			if err == nil {
+				t.Fatal("Fetch succeeded, want an error")
+			}
+			if result == nil || result.Feed != nil || result.NotModified {
+				t.Fatalf("result = %+v, want metadata only", result)
+			}
+			if result.Meta.LastStatus != tt.wantStatus {
+				t.Errorf("status = %d, want %d", result.Meta.LastStatus, tt.wantStatus)
+			}
+			if result.Meta.ETag != testETag {
+				t.Errorf("ETag = %q, want the stored validator kept", result.Meta.ETag)
+			}
+		})
+	}
+}
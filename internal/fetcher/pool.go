@@ -0,0 +1,176 @@
+package fetcher
+
+import (
+	"context"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/thedittmer/rss-reader/internal/models"
+)
+
+// Options controls how feeds are scheduled by FetchAll
+type Options struct {
+	Workers   int           // Maximum number of concurrent requests
+	Timeout   time.Duration // Timeout for a single feed request
+	PerHost   int           // Maximum number of concurrent requests to one host
+	HostDelay time.Duration // Minimum delay between requests to one host
+}
+
+func DefaultOptions() Options {
+	return Options{
+		Workers:   8,
+		Timeout:   20 * time.Second,
+		PerHost:   2,
+		HostDelay: 250 * time.Millisecond,
+	}
+}
+
+// Job is a single feed to fetch
+type Job struct {
+	URL  string
+	Meta models.FeedMeta
+}
+
+// Outcome is the result of running a Job
+type Outcome struct {
+	URL      string
+	Result   *Result
+	Err      error
+	Duration time.Duration
+}
+
+// FetchAll fetches every job using a bounded worker pool and returns the
+// outcomes in the same order as jobs. Cancelling ctx aborts requests in
+// flight; jobs that never started report ctx.Err().
+func (f *Fetcher) FetchAll(ctx context.Context, jobs []Job) []Outcome {
+	opts := f.Options.withDefaults()
+	outcomes := make([]Outcome, len(jobs))
+	hosts := newHostLimiter(opts.PerHost, opts.HostDelay)
+
+	queue := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(opts.Workers, len(jobs)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range queue {
+				outcomes[i] = f.run(ctx, jobs[i], hosts)
+			}
+		}()
+	}
+
+	for i := range jobs {
+		queue <- i
+	}
+	close(queue)
+	wg.Wait()
+
+	return outcomes
+}
+
+func (f *Fetcher) run(ctx context.Context, job Job, hosts *hostLimiter) Outcome {
+	start := time.Now()
+	outcome := Outcome{URL: job.URL}
+
+	host := job.URL
+	if u, err := url.Parse(job.URL); err == nil && u.Host != "" {
+		host = u.Host
+	}
+
+	if err := hosts.acquire(ctx, host); err != nil {
+		outcome.Err = err
+		outcome.Duration = time.Since(start)
+		return outcome
+	}
+	defer hosts.release(host)
+
+	outcome.Result, outcome.Err = f.Fetch(ctx, job.URL, job.Meta)
+	outcome.Duration = time.Since(start)
+	return outcome
+}
+
+func (o Options) withDefaults() Options {
+	defaults := DefaultOptions()
+	if o.Workers <= 0 {
+		o.Workers = defaults.Workers
+	}
+	if o.Timeout <= 0 {
+		o.Timeout = defaults.Timeout
+	}
+	if o.PerHost <= 0 {
+		o.PerHost = defaults.PerHost
+	}
+	if o.HostDelay < 0 {
+		o.HostDelay = 0
+	}
+	return o
+}
+
+// hostLimiter bounds concurrency and spaces out requests per host
+type hostLimiter struct {
+	mu      sync.Mutex
+	slots   map[string]chan struct{}
+	next    map[string]time.Time
+	perHost int
+	delay   time.Duration
+}
+
+func newHostLimiter(perHost int, delay time.Duration) *hostLimiter {
+	return &hostLimiter{
+		slots:   make(map[string]chan struct{}),
+		next:    make(map[string]time.Time),
+		perHost: perHost,
+		delay:   delay,
+	}
+}
+
+func (h *hostLimiter) acquire(ctx context.Context, host string) error {
+	h.mu.Lock()
+	sem, ok := h.slots[host]
+	if !ok {
+		sem = make(chan struct{}, h.perHost)
+		h.slots[host] = sem
+	}
+	h.mu.Unlock()
+
+	select {
+	case sem <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	// A free slot may have been picked over an already cancelled context
+	if err := ctx.Err(); err != nil {
+		<-sem
+		return err
+	}
+
+	// Reserve the next start time for this host
+	h.mu.Lock()
+	now := time.Now()
+	start := h.next[host]
+	if start.Before(now) {
+		start = now
+	}
+	h.next[host] = start.Add(h.delay)
+	h.mu.Unlock()
+
+	if wait := time.Until(start); wait > 0 {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			<-sem
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+func (h *hostLimiter) release(host string) {
+	h.mu.Lock()
+	sem := h.slots[host]
+	h.mu.Unlock()
+	<-sem
+}
@@ -0,0 +1,139 @@
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// concurrencyServer serves testFeed slowly and records the most requests
+// it handled at once and when each request started
+type concurrencyServer struct {
+	delay time.Duration
+
+	mu      sync.Mutex
+	active  int
+	peak    int
+	started []time.Time
+}
+
+func (s *concurrencyServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.active++
+	s.peak = max(s.peak, s.active)
+	s.started = append(s.started, time.Now())
+	s.mu.Unlock()
+
+	time.Sleep(s.delay)
+	fmt.Fprint(w, testFeed)
+
+	s.mu.Lock()
+	s.active--
+	s.mu.Unlock()
+}
+
+func jobsFor(serverURL string, n int) []Job {
+	jobs := make([]Job, n)
+	for i := range jobs {
+		jobs[i] = Job{URL: fmt.Sprintf("%s/feed/%d", serverURL, i)}
+	}
+	return jobs
+}
+
+func TestFetchAllLimitsRequestsPerHost(t *testing.T) {
+	first := &concurrencyServer{delay: 50 * time.Millisecond}
+	second := &concurrencyServer{delay: 50 * time.Millisecond}
+	firstServer := httptest.NewServer(first)
+	defer firstServer.Close()
+	secondServer := httptest.NewServer(second)
+	defer secondServer.Close()
+
+	// Each test server listens on its own port, so counts as its own host
+	jobs := append(jobsFor(firstServer.URL, 6), jobsFor(secondServer.URL, 6)...)
+	f := New(Options{Workers: 12, PerHost: 2})
+	outcomes := f.FetchAll(context.Background(), jobs)
+
+	for i, outcome := range outcomes {
+		if outcome.URL != jobs[i].URL {
+			t.Errorf("outcome %d is for %s, want %s", i, outcome.URL, jobs[i].URL)
+		}
+		if outcome.Err != nil {
+			t.Errorf("%s: %v", outcome.URL, outcome.Err)
+		}
+	}
+	for name, server := range map[string]*concurrencyServer{"first": first, "second": second} {
+		if server.peak != 2 {
+			t.Errorf("%s host handled %d requests at once, want 2", name, server.peak)
+		}
+	}
+}
+
+func TestFetchAllSpacesRequestsToAHost(t *testing.T) {
+	handler := &concurrencyServer{}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	delay := 40 * time.Millisecond
+	f := New(Options{Workers: 3, PerHost: 3, HostDelay: delay})
+	f.FetchAll(context.Background(), jobsFor(server.URL, 3))
+
+	if len(handler.started) != 3 {
+		t.Fatalf("server got %d requests, want 3", len(handler.started))
+	}
+	for i := 1; i < len(handler.started); i++ {
+		// Allow for timer granularity
+		if gap := handler.started[i].Sub(handler.started[i-1]); gap < delay-5*time.Millisecond {
+			t.Errorf("request %d started %v after the previous one, want at least %v", i, gap, delay)
+		}
+	}
+}
+
+func TestFetchAllCancel(t *testing.T) {
+	arrived := make(chan struct{}, 1)
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case arrived <- struct{}{}:
+		default:
+		}
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-arrived
+		cancel()
+	}()
+
+	f := New(Options{Workers: 1, PerHost: 1})
+	done := make(chan []Outcome)
+	go func() { done <- f.FetchAll(ctx, jobsFor(server.URL, 3)) }()
+
+	var outcomes []Outcome
+	select {
+	case outcomes = <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("FetchAll did not return after cancellation")
+	}
+
+	// The request in flight is aborted and the others never start
+	if outcomes[0].Err == nil {
+		t.Error("request in flight succeeded, want it aborted")
+	}
+	for _, outcome := range outcomes[1:] {
+		if !errors.Is(outcome.Err, context.Canceled) || outcome.Result != nil {
+			t.Errorf("%s: result %+v, error %v; want context.Canceled", outcome.URL, outcome.Result, outcome.Err)
+		}
+	}
+}
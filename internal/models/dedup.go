@@ -0,0 +1,160 @@
+package models
+
+import (
+	"net/url"
+	"strings"
+	"unicode"
+)
+
+// Query parameters that only track where a click came from
+var trackingParams = map[string]bool{
+	"fbclid": true, "gclid": true, "dclid": true, "msclkid": true,
+	"mc_cid": true, "mc_eid": true, "igshid": true, "yclid": true,
+	"ref": true, "ref_src": true, "ref_url": true, "_hsenc": true, "_hsmi": true,
+}
+
+// Titles with fewer words than this are too generic to match on
+const minTitleWords = 4
+
+// Titles from different feeds match when this share of their distinct words,
+// by Jaccard index, is the same
+const titleSimilarity = 0.7
+
+// CanonicalURL normalizes a link for duplicate detection. The scheme is
+// ignored, the host is lowercased without "www." or a default port, tracking
+// parameters and fragments are removed, and trailing slashes are trimmed.
+func CanonicalURL(link string) string {
+	link = strings.TrimSpace(link)
+	u, err := url.Parse(link)
+	if err != nil || u.Host == "" {
+		return strings.ToLower(link)
+	}
+
+	host := strings.ToLower(u.Hostname())
+	host = strings.TrimPrefix(host, "www.")
+	if port := u.Port(); port != "" && port != "80" && port != "443" {
+		host += ":" + port
+	}
+
+	query := u.Query()
+	for param := range query {
+		lower := strings.ToLower(param)
+		if strings.HasPrefix(lower, "utm_") || trackingParams[lower] {
+			query.Del(param)
+		}
+	}
+
+	canonical := host + strings.TrimRight(u.EscapedPath(), "/")
+	if encoded := query.Encode(); encoded != "" {
+		canonical += "?" + encoded
+	}
+	return canonical
+}
+
+// titleWords returns the distinct lowercased words of a title, or nil if the
+// title is too short to compare reliably
+func titleWords(title string) map[string]bool {
+	words := strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+	if len(words) < minTitleWords {
+		return nil
+	}
+	set := make(map[string]bool, len(words))
+	for _, word := range words {
+		set[word] = true
+	}
+	return set
+}
+
+// Dedupe collapses items that point to the same article. Items match when
+// their canonical links are equal, or when items from different feeds have
+// similar titles. The first item of each group is kept and records the
+// titles of all feeds the article appeared in and the keys of the copies,
+// so their read state carries over.
+func Dedupe(items []FeedItem) []FeedItem {
+	var result []FeedItem
+	var titles []map[string]bool // Title words of each result
+	byURL := make(map[string]int)
+	byWord := make(map[string][]int) // Results whose title has the word
+
+	for _, item := range items {
+		link := ""
+		if item.Link != "" {
+			link = CanonicalURL(item.Link)
+		}
+		words := titleWords(item.Title)
+
+		i, ok := byURL[link]
+		if !ok || link == "" {
+			i, ok = similarTitle(words, item.FeedURL, result, titles, byWord)
+		}
+
+		if ok {
+			result[i].addSource(item.FeedSource)
+			result[i].MergedKeys = append(result[i].MergedKeys, item.Key())
+			continue
+		}
+
+		item.Sources = nil
+		item.MergedKeys = nil
+		item.addSource(item.FeedSource)
+		result = append(result, item)
+		titles = append(titles, words)
+		if link != "" {
+			byURL[link] = len(result) - 1
+		}
+		for word := range words {
+			byWord[word] = append(byWord[word], len(result)-1)
+		}
+	}
+
+	return result
+}
+
+// similarTitle returns the result from another feed whose title is most
+// similar to words, if any is similar enough
+func similarTitle(words map[string]bool, feedURL string, result []FeedItem, titles []map[string]bool, byWord map[string][]int) (int, bool) {
+	if words == nil {
+		return 0, false
+	}
+
+	shared := make(map[int]int)
+	for word := range words {
+		for _, i := range byWord[word] {
+			shared[i]++
+		}
+	}
+
+	best, bestScore := 0, 0.0
+	for i, count := range shared {
+		if result[i].FeedURL == feedURL {
+			continue
+		}
+		score := float64(count) / float64(len(words)+len(titles[i])-count)
+		if score > bestScore || (score == bestScore && i < best) {
+			best, bestScore = i, score
+		}
+	}
+	return best, bestScore >= titleSimilarity
+}
+
+func (i *FeedItem) addSource(source string) {
+	if source == "" {
+		return
+	}
+	for _, existing := range i.Sources {
+		if existing == source {
+			return
+		}
+	}
+	i.Sources = append(i.Sources, source)
+}
+
+// SourceLabel names every feed the item appeared in
+func (i FeedItem) SourceLabel() string {
+	if len(i.Sources) > 1 {
+		return strings.Join(i.Sources, ", ")
+	}
+	return i.FeedSource
+}
@@ -0,0 +1,180 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCanonicalURL(t *testing.T) {
+	tests := []struct {
+		name string
+		link string
+		want string
+	}{
+		{"plain", "https://example.com/post", "example.com/post"},
+		{"scheme ignored", "http://example.com/post", "example.com/post"},
+		{"www removed", "https://www.example.com/post", "example.com/post"},
+		{"host lowercased", "https://Example.COM/Post", "example.com/Post"},
+		{"trailing slashes", "https://example.com/post//", "example.com/post"},
+		{"root path", "https://example.com/", "example.com"},
+		{"default port", "https://example.com:443/post", "example.com/post"},
+		{"other port kept", "http://example.com:8080/post", "example.com:8080/post"},
+		{"fragment removed", "https://example.com/post#comments", "example.com/post"},
+		{"utm params removed", "https://example.com/post?utm_source=rss&utm_medium=feed", "example.com/post"},
+		{"tracking params removed", "https://example.com/post?fbclid=abc&ref=hn", "example.com/post"},
+		{"tracking param case", "https://example.com/post?UTM_Campaign=x&GCLID=y", "example.com/post"},
+		{"other params kept", "https://example.com/post?id=7&utm_source=rss", "example.com/post?id=7"},
+		{"params sorted", "https://example.com/post?b=2&a=1", "example.com/post?a=1&b=2"},
+		{"surrounding space", "  https://example.com/post  ", "example.com/post"},
+		{"not a URL", "Some Title", "some title"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CanonicalURL(tt.link); got != tt.want {
+				t.Errorf("CanonicalURL(%q) = %q, want %q", tt.link, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDedupe(t *testing.T) {
+	item := func(id, feed, link, title string) FeedItem {
+		return FeedItem{ID: id, FeedURL: "https://" + feed + "/rss", FeedSource: feed, Link: link, Title: title}
+	}
+
+	tests := []struct {
+		name  string
+		items []FeedItem
+		want  [][]string // Keys of each kept item and the copies merged into it
+	}{
+		{
+			name: "same link across schemes and tracking",
+			items: []FeedItem{
+				item("a", "one", "https://www.example.com/post/?utm_source=one", "First title"),
+				item("b", "two", "http://example.com/post#top", "Another headline"),
+			},
+			want: [][]string{{"a", "b"}},
+		},
+		{
+			name: "different links kept",
+			items: []FeedItem{
+				item("a", "one", "https://example.com/one", "Short"),
+				item("b", "two", "https://example.com/two", "Short"),
+			},
+			want: [][]string{{"a"}, {"b"}},
+		},
+		{
+			name: "reordered title from another feed",
+			items: []FeedItem{
+				item("a", "one", "https://one.com/1", "Go 1.22 is released today"),
+				item("b", "two", "https://two.com/2", "Today: Go 1.22 is released!"),
+			},
+			want: [][]string{{"a", "b"}},
+		},
+		{
+			name: "title with an extra word",
+			items: []FeedItem{
+				item("a", "one", "https://one.com/1", "Apple announces new iPhone at event"),
+				item("b", "two", "https://two.com/2", "Apple announces the new iPhone at event"),
+			},
+			want: [][]string{{"a", "b"}},
+		},
+		{
+			name: "titles differing in one of few words",
+			items: []FeedItem{
+				item("a", "one", "https://one.com/1", "Rust 1.80 released today"),
+				item("b", "two", "https://two.com/2", "Rust 1.81 released today"),
+			},
+			want: [][]string{{"a"}, {"b"}},
+		},
+		{
+			name: "similar titles in the same feed",
+			items: []FeedItem{
+				item("a", "one", "https://one.com/1", "Weekly roundup of the news"),
+				item("b", "one", "https://one.com/2", "Weekly roundup of the news"),
+			},
+			want: [][]string{{"a"}, {"b"}},
+		},
+		{
+			name: "short titles not compared",
+			items: []FeedItem{
+				item("a", "one", "https://one.com/1", "Hello world"),
+				item("b", "two", "https://two.com/2", "Hello world"),
+			},
+			want: [][]string{{"a"}, {"b"}},
+		},
+		{
+			name: "most similar title wins",
+			items: []FeedItem{
+				item("a", "one", "https://one.com/1", "New release of the editor with plugins"),
+				item("b", "one", "https://one.com/2", "New release of the editor with themes and plugins"),
+				item("c", "two", "https://two.com/3", "New release of the editor with themes and plugins today"),
+			},
+			want: [][]string{{"a"}, {"b", "c"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got [][]string
+			for _, kept := range Dedupe(tt.items) {
+				got = append(got, kept.Keys())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Dedupe = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDedupeRecordsSources(t *testing.T) {
+	items := []FeedItem{
+		{ID: "a", FeedURL: "https://one/rss", FeedSource: "One", Link: "https://example.com/post"},
+		{ID: "b", FeedURL: "https://two/rss", FeedSource: "Two", Link: "https://example.com/post"},
+		{ID: "c", FeedURL: "https://one/rss", FeedSource: "One", Link: "https://example.com/post/"},
+	}
+
+	result := Dedupe(items)
+	if len(result) != 1 {
+		t.Fatalf("got %d items, want 1", len(result))
+	}
+	if got := result[0].SourceLabel(); got != "One, Two" {
+		t.Errorf("SourceLabel = %q, want %q", got, "One, Two")
+	}
+
+	// The input is not modified
+	if items[0].Sources != nil || items[0].MergedKeys != nil {
+		t.Errorf("input item changed: %+v", items[0])
+	}
+}
+
+func TestDedupeMergesReadState(t *testing.T) {
+	items := []FeedItem{
+		{ID: "a", FeedURL: "https://one/rss", Link: "https://example.com/post"},
+		{ID: "b", FeedURL: "https://two/rss", Link: "https://www.example.com/post"},
+	}
+	profile := NewUserProfile()
+
+	// Reading any copy marks the merged article read
+	profile.ReadArticles["b"] = true
+	merged := Dedupe(items)[0]
+	if !profile.IsRead(merged) {
+		t.Error("merged article unread although a copy was read")
+	}
+
+	// Marking it unread clears every copy, so none shows up read on its own
+	profile.MarkUnread(merged)
+	for _, item := range items {
+		if profile.IsRead(item) {
+			t.Errorf("copy %s still read", item.ID)
+		}
+	}
+
+	profile.MarkRead(merged)
+	for _, item := range items {
+		if !profile.IsRead(item) {
+			t.Errorf("copy %s not marked read", item.ID)
+		}
+	}
+}
@@ -1,15 +1,248 @@
 package models
 
 import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
 	"time"
 )
 
 type FeedItem struct {
+	ID          string
 	Title       string
 	Description string
+	Content     string
 	Link        string
+	Author      string
+	Categories  []string
+	Enclosures  []Enclosure
 	Published   time.Time
-	FeedSource  string
+	FeedSource  string // Title of the originating feed
+	FeedURL     string // URL of the originating feed
+
+	DateEstimated bool      // Published was estimated from FirstSeen
+	FirstSeen     time.Time // When the item was first fetched
+
+	// Titles of every feed the article appeared in and keys of the copies
+	// merged into this one, set when duplicates across feeds are merged
+	Sources    []string `json:"-"`
+	MergedKeys []string `json:"-"`
+}
+
+// Enclosure is a media file attached to a feed item
+type Enclosure struct {
+	URL    string
+	Type   string
+	Length string
+}
+
+// NewItemID returns a stable identifier for a feed item. GUIDs are only
+// unique within a feed, so the ID is a hash of the feed URL and the GUID when
+// the feed provides one, otherwise a hash of the link and title.
+func NewItemID(feedURL, guid, link, title string) string {
+	var sum [sha1.Size]byte
+	if guid != "" {
+		sum = sha1.Sum([]byte(feedURL + "\x00" + guid))
+	} else {
+		sum = sha1.Sum([]byte(link + "\n" + title))
+	}
+	return "sha1:" + hex.EncodeToString(sum[:])
+}
+
+// Key returns the identifier used to match an item across refreshes. Items
+// without an ID fall back to their link.
+func (i FeedItem) Key() string {
+	if i.ID != "" {
+		return i.ID
+	}
+	if i.Link != "" {
+		return i.Link
+	}
+	return i.FeedSource + "|" + i.Title
+}
+
+// Keys returns the item's key followed by the keys of the duplicates merged
+// into it
+func (i FeedItem) Keys() []string {
+	return append([]string{i.Key()}, i.MergedKeys...)
+}
+
+// CountNew returns how many items of after were not in before, matching
+// items by their own key and the keys of the duplicates merged into them
+func CountNew(before, after []FeedItem) int {
+	seen := make(map[string]bool, len(before))
+	for _, item := range before {
+		for _, key := range item.Keys() {
+			seen[key] = true
+		}
+	}
+
+	count := 0
+	for _, item := range after {
+		isNew := true
+		for _, key := range item.Keys() {
+			if seen[key] {
+				isNew = false
+				break
+			}
+		}
+		if isNew {
+			count++
+		}
+	}
+	return count
+}
+
+// Feed is a subscription and its settings
+type Feed struct {
+	URL      string
+	Title    string // Display title; the feed's own title is used when empty
+	Category string
+	Enabled  bool // Disabled feeds are not fetched, but keep their articles
+
+	// RefreshInterval is the minimum time between fetches; 0 fetches the
+	// feed on every refresh
+	RefreshInterval time.Duration
+	MaxItems        int // Newest items kept; 0 uses the global limit
+
+	// Aggregator marks feeds whose item dates are unreliable, so dates are
+	// estimated from when items were first seen instead
+	Aggregator bool
+
+	Tags []string
+}
+
+// NewFeed returns an enabled feed with default settings
+func NewFeed(url string) Feed {
+	return Feed{URL: url, Enabled: true}
+}
+
+// feedJSON is the stored form of a Feed, with the interval as a string
+// such as "2h"
+type feedJSON struct {
+	URL             string   `json:"url"`
+	Title           string   `json:"title,omitempty"`
+	Category        string   `json:"category,omitempty"`
+	Enabled         bool     `json:"enabled"`
+	RefreshInterval string   `json:"refreshInterval,omitempty"`
+	MaxItems        int      `json:"maxItems,omitempty"`
+	Aggregator      bool     `json:"aggregator,omitempty"`
+	Tags            []string `json:"tags,omitempty"`
+}
+
+func (f Feed) MarshalJSON() ([]byte, error) {
+	stored := feedJSON{
+		URL:        f.URL,
+		Title:      f.Title,
+		Category:   f.Category,
+		Enabled:    f.Enabled,
+		MaxItems:   f.MaxItems,
+		Aggregator: f.Aggregator,
+		Tags:       f.Tags,
+	}
+	if f.RefreshInterval > 0 {
+		stored.RefreshInterval = f.RefreshInterval.String()
+	}
+	return json.Marshal(stored)
+}
+
+// UnmarshalJSON reads a stored feed; feeds without an enabled field are enabled
+func (f *Feed) UnmarshalJSON(data []byte) error {
+	stored := feedJSON{Enabled: true}
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return err
+	}
+
+	var interval time.Duration
+	if stored.RefreshInterval != "" {
+		parsed, err := time.ParseDuration(stored.RefreshInterval)
+		if err != nil {
+			return fmt.Errorf("invalid refresh interval for %s: %w", stored.URL, err)
+		}
+		interval = parsed
+	}
+
+	*f = Feed{
+		URL:             stored.URL,
+		Title:           stored.Title,
+		Category:        stored.Category,
+		Enabled:         stored.Enabled,
+		RefreshInterval: interval,
+		MaxItems:        stored.MaxItems,
+		Aggregator:      stored.Aggregator,
+		Tags:            stored.Tags,
+	}
+	return nil
+}
+
+// DisplayTitle returns the custom title, else the feed's own title, else the URL
+func (f Feed) DisplayTitle(meta FeedMeta) string {
+	switch {
+	case f.Title != "":
+		return f.Title
+	case meta.Title != "":
+		return meta.Title
+	default:
+		return f.URL
+	}
+}
+
+// Due reports whether the feed's refresh interval has passed since it was
+// last fetched
+func (f Feed) Due(meta FeedMeta, now time.Time) bool {
+	return f.RefreshInterval <= 0 || meta.LastFetched.IsZero() ||
+		now.Sub(meta.LastFetched) >= f.RefreshInterval
+}
+
+// Categories returns the distinct categories of the feeds in alphabetical order
+func Categories(feeds []Feed) []string {
+	seen := make(map[string]bool)
+	var categories []string
+	for _, feed := range feeds {
+		if feed.Category != "" && !seen[feed.Category] {
+			seen[feed.Category] = true
+			categories = append(categories, feed.Category)
+		}
+	}
+	sort.Strings(categories)
+	return categories
+}
+
+// FeedMeta holds per-feed fetch metadata used for conditional requests
+type FeedMeta struct {
+	URL          string
+	ETag         string
+	LastModified string
+	LastStatus   int
+	LastFetched  time.Time
+
+	// Channel information reported by the feed itself
+	Title       string
+	SiteLink    string
+	Description string
+	Updated     time.Time
+}
+
+// RefreshStatus describes how refreshing a feed ended
+type RefreshStatus string
+
+const (
+	RefreshOK          RefreshStatus = "ok"
+	RefreshNotModified RefreshStatus = "not modified"
+	RefreshFailed      RefreshStatus = "failed"
+	RefreshSkipped     RefreshStatus = "skipped" // Disabled, or not due yet
+)
+
+// RefreshResult is the outcome of refreshing a single feed
+type RefreshResult struct {
+	URL        string
+	Status     RefreshStatus
+	StatusCode int
+	ItemCount  int
+	Duration   time.Duration
+	Err        error
 }
 
 type SearchOptions struct {
@@ -25,6 +258,7 @@ type ArticleScore struct {
 
 type SearchResult struct {
 	Item       FeedItem
-	Matches    []string
-	MatchCount int
+	Matches    []string // Indexed words that matched the query
+	MatchCount int      // Query terms the item matched
+	Score      float64
 }
@@ -68,6 +68,41 @@ func (p *UserProfile) UpdateInterests(text string) {
 	p.LastUpdated = time.Now()
 }
 
+// IsRead reports whether the article, or any duplicate merged into it, has
+// been read
+func (p *UserProfile) IsRead(item FeedItem) bool {
+	for _, key := range item.Keys() {
+		if p.ReadArticles[key] {
+			return true
+		}
+	}
+	return false
+}
+
+// MarkRead records the article and its merged duplicates as read
+func (p *UserProfile) MarkRead(item FeedItem) {
+	for _, key := range item.Keys() {
+		p.ReadArticles[key] = true
+	}
+}
+
+// MarkUnread clears the read state of the article and its merged duplicates
+func (p *UserProfile) MarkUnread(item FeedItem) {
+	for _, key := range item.Keys() {
+		delete(p.ReadArticles, key)
+	}
+}
+
+// ToggleRead flips the read state of an article and returns the new state
+func (p *UserProfile) ToggleRead(item FeedItem) bool {
+	if p.IsRead(item) {
+		p.MarkUnread(item)
+		return false
+	}
+	p.MarkRead(item)
+	return true
+}
+
 func extractKeywords(text string) []string {
 	words := strings.Fields(strings.ToLower(text))
 	keywords := make([]string, 0)
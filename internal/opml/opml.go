@@ -0,0 +1,142 @@
+package opml
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Document is an OPML 2.0 file
+type Document struct {
+	XMLName xml.Name `xml:"opml"`
+	Version string   `xml:"version,attr"`
+	Head    Head     `xml:"head"`
+	Body    Body     `xml:"body"`
+}
+
+type Head struct {
+	Title       string `xml:"title,omitempty"`
+	DateCreated string `xml:"dateCreated,omitempty"`
+}
+
+type Body struct {
+	Outlines []Outline `xml:"outline"`
+}
+
+// Outline is either a subscription (it has an xmlUrl) or a folder of outlines
+type Outline struct {
+	Text     string    `xml:"text,attr"`
+	Title    string    `xml:"title,attr,omitempty"`
+	Type     string    `xml:"type,attr,omitempty"`
+	XMLURL   string    `xml:"xmlUrl,attr,omitempty"`
+	HTMLURL  string    `xml:"htmlUrl,attr,omitempty"`
+	Outlines []Outline `xml:"outline"`
+}
+
+// Subscription is a feed found in, or written to, an OPML file
+type Subscription struct {
+	URL     string
+	Title   string
+	SiteURL string
+	Folder  string // Titles of the enclosing folders joined with "/"
+}
+
+// Read returns every subscription in an OPML document, in document order
+func Read(r io.Reader) ([]Subscription, error) {
+	var doc Document
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("error parsing OPML: %w", err)
+	}
+
+	var subs []Subscription
+	var walk func(outlines []Outline, folder string)
+	walk = func(outlines []Outline, folder string) {
+		for _, o := range outlines {
+			title := strings.TrimSpace(o.Title)
+			if title == "" {
+				title = strings.TrimSpace(o.Text)
+			}
+
+			if url := strings.TrimSpace(o.XMLURL); url != "" {
+				subs = append(subs, Subscription{
+					URL:     url,
+					Title:   title,
+					SiteURL: strings.TrimSpace(o.HTMLURL),
+					Folder:  folder,
+				})
+				continue
+			}
+
+			// Outlines without a feed URL are folders
+			child := folder
+			if title != "" {
+				if child != "" {
+					child += "/"
+				}
+				child += title
+			}
+			walk(o.Outlines, child)
+		}
+	}
+	walk(doc.Body.Outlines, "")
+
+	return subs, nil
+}
+
+// Write encodes subscriptions as an OPML 2.0 document, nesting them in
+// folder outlines by their Folder path
+func Write(w io.Writer, title string, subs []Subscription) error {
+	doc := Document{
+		Version: "2.0",
+		Head: Head{
+			Title:       title,
+			DateCreated: time.Now().Format(time.RFC1123Z),
+		},
+	}
+
+	for _, sub := range subs {
+		outlines := &doc.Body.Outlines
+		if sub.Folder != "" {
+			for _, name := range strings.Split(sub.Folder, "/") {
+				outlines = &folderOutline(outlines, name).Outlines
+			}
+		}
+
+		text := sub.Title
+		if text == "" {
+			text = sub.URL
+		}
+		*outlines = append(*outlines, Outline{
+			Text:    text,
+			Title:   sub.Title,
+			Type:    "rss",
+			XMLURL:  sub.URL,
+			HTMLURL: sub.SiteURL,
+		})
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("error writing OPML: %w", err)
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// folderOutline returns the folder with the given name, appending it if needed
+func folderOutline(outlines *[]Outline, name string) *Outline {
+	for i := range *outlines {
+		o := &(*outlines)[i]
+		if o.XMLURL == "" && o.Text == name {
+			return o
+		}
+	}
+	*outlines = append(*outlines, Outline{Text: name, Title: name})
+	return &(*outlines)[len(*outlines)-1]
+}
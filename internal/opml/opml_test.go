@@ -0,0 +1,101 @@
+package opml
+
+import (
+	"bytes"
+	"encoding/xml"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWriteReadRoundTrip(t *testing.T) {
+	subs := []Subscription{
+		{URL: "https://top.example/rss", Title: "Top"},
+		{URL: "https://go.example/feed", Title: "Go Blog", SiteURL: "https://go.example/", Folder: "Tech"},
+		{URL: "https://rust.example/feed", Title: "Rust", Folder: "Tech/Languages"},
+		{URL: "https://py.example/feed", Folder: "Tech/Languages"},
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, "Subscriptions", subs); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	got, err := Read(&buf)
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+
+	// A feed without a title is written with its URL as the outline text
+	want := append([]Subscription(nil), subs...)
+	want[3].Title = want[3].URL
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("round trip = %+v, want %+v", got, want)
+	}
+}
+
+func TestWriteNestsFolders(t *testing.T) {
+	subs := []Subscription{
+		{URL: "https://one.example/rss", Title: "One", Folder: "A/B"},
+		{URL: "https://two.example/rss", Title: "Two", Folder: "A"},
+		{URL: "https://three.example/rss", Title: "Three", Folder: "A/B"},
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, "", subs); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	var doc Document
+	if err := xml.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatal(err)
+	}
+	if len(doc.Body.Outlines) != 1 || doc.Body.Outlines[0].Text != "A" {
+		t.Fatalf("top level = %+v, want the single folder A", doc.Body.Outlines)
+	}
+	folderA := doc.Body.Outlines[0]
+	if len(folderA.Outlines) != 2 || folderA.Outlines[0].Text != "B" || folderA.Outlines[1].XMLURL != subs[1].URL {
+		t.Fatalf("folder A = %+v, want folder B and feed Two", folderA.Outlines)
+	}
+	if folderB := folderA.Outlines[0]; len(folderB.Outlines) != 2 {
+		t.Errorf("folder B holds %d outlines, want 2", len(folderB.Outlines))
+	}
+}
+
+func TestReadOutlinesWithoutFeedURL(t *testing.T) {
+	const doc = `<?xml version="1.0"?>
+<opml version="1.0">
+  <head><title>Feeds</title></head>
+  <body>
+    <outline text="Empty folder"/>
+    <outline title="News">
+      <outline text="World">
+        <outline text="Deep" xmlUrl=" https://deep.example/rss "/>
+      </outline>
+      <outline text="Site only" htmlUrl="https://site.example/"/>
+      <outline text="  " xmlUrl="https://blank.example/rss"/>
+    </outline>
+    <outline>
+      <outline text="Loose" xmlUrl="https://loose.example/rss"/>
+    </outline>
+  </body>
+</opml>`
+
+	got, err := Read(strings.NewReader(doc))
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	want := []Subscription{
+		{URL: "https://deep.example/rss", Title: "Deep", Folder: "News/World"},
+		{URL: "https://blank.example/rss", Folder: "News"},
+		{URL: "https://loose.example/rss", Title: "Loose"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Read = %+v, want %+v", got, want)
+	}
+}
+
+func TestReadInvalid(t *testing.T) {
+	if _, err := Read(strings.NewReader("not xml")); err == nil {
+		t.Error("Read succeeded on invalid input, want an error")
+	}
+}
@@ -0,0 +1,356 @@
+package search
+
+import (
+	"html"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/thedittmer/rss-reader/internal/models"
+)
+
+// BM25 parameters: k1 limits how much repeating a term adds, b how much
+// long articles are penalized
+const (
+	k1 = 1.2
+	b  = 0.75
+)
+
+// Terms in these fields count as if they appeared this many times
+const (
+	titleWeight       = 3
+	authorWeight      = 2
+	categoryWeight    = 2
+	descriptionWeight = 1
+)
+
+// Query terms of at least this length also match longer words they start;
+// such matches count for prefixWeight of an exact match
+const (
+	minPrefixLength = 3
+	prefixWeight    = 0.5
+)
+
+// Index is an inverted index over article titles, descriptions, authors and
+// categories, ranked with BM25. It is not safe for concurrent use.
+type Index struct {
+	docs        map[string]*document      // By item key
+	postings    map[string]map[string]int // Term to item key to weighted frequency
+	totalLength int
+}
+
+type document struct {
+	item    models.FeedItem
+	terms   map[string]int
+	length  int
+	content string // Indexed text, to detect changed articles
+}
+
+// NewIndex returns an empty index
+func NewIndex() *Index {
+	return &Index{
+		docs:     make(map[string]*document),
+		postings: make(map[string]map[string]int),
+	}
+}
+
+// Len returns the number of indexed articles
+func (ix *Index) Len() int {
+	return len(ix.docs)
+}
+
+// Update makes the index match items. Only articles that are new or whose
+// text changed are tokenized again; articles missing from items are dropped.
+func (ix *Index) Update(items []models.FeedItem) {
+	seen := make(map[string]bool, len(items))
+	for _, item := range items {
+		key := item.Key()
+		seen[key] = true
+
+		content := indexedContent(item)
+		if doc, ok := ix.docs[key]; ok {
+			if doc.content == content {
+				doc.item = item // Read state, sources and dates may change
+				continue
+			}
+			ix.remove(key)
+		}
+		ix.add(key, item, content)
+	}
+
+	for key := range ix.docs {
+		if !seen[key] {
+			ix.remove(key)
+		}
+	}
+}
+
+func indexedContent(item models.FeedItem) string {
+	return strings.Join([]string{
+		item.Title, item.Description, item.Author, strings.Join(item.Categories, "\x1f"),
+	}, "\x00")
+}
+
+func (ix *Index) add(key string, item models.FeedItem, content string) {
+	terms := make(map[string]int)
+	addTerms := func(text string, weight int) {
+		for _, term := range Tokenize(text) {
+			terms[term] += weight
+		}
+	}
+	addTerms(item.Title, titleWeight)
+	addTerms(item.Author, authorWeight)
+	for _, category := range item.Categories {
+		addTerms(category, categoryWeight)
+	}
+	addTerms(item.Description, descriptionWeight)
+
+	length := 0
+	for term, freq := range terms {
+		length += freq
+		postings := ix.postings[term]
+		if postings == nil {
+			postings = make(map[string]int)
+			ix.postings[term] = postings
+		}
+		postings[key] = freq
+	}
+
+	ix.docs[key] = &document{item: item, terms: terms, length: length, content: content}
+	ix.totalLength += length
+}
+
+func (ix *Index) remove(key string) {
+	doc, ok := ix.docs[key]
+	if !ok {
+		return
+	}
+	for term := range doc.terms {
+		postings := ix.postings[term]
+		delete(postings, key)
+		if len(postings) == 0 {
+			delete(ix.postings, term)
+		}
+	}
+	ix.totalLength -= doc.length
+	delete(ix.docs, key)
+}
+
+// Search returns the articles matching any term of the query, best first.
+// Articles matching more of the query's terms rank higher. Matches holds
+// the indexed words that matched, for highlighting.
+func (ix *Index) Search(query string) []models.SearchResult {
+	queryTerms := unique(Tokenize(query))
+	if len(queryTerms) == 0 || len(ix.docs) == 0 {
+		return nil
+	}
+
+	n := float64(len(ix.docs))
+	avgLength := float64(ix.totalLength) / n
+
+	type hit struct {
+		score   float64
+		matched map[string]bool // Query terms matched
+		words   map[string]bool // Indexed words matched
+	}
+	hits := make(map[string]*hit)
+
+	for _, queryTerm := range queryTerms {
+		for term, weight := range ix.expand(queryTerm) {
+			postings := ix.postings[term]
+			df := float64(len(postings))
+			idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+
+			for key, freq := range postings {
+				doc := ix.docs[key]
+				tf := float64(freq)
+				norm := tf * (k1 + 1) / (tf + k1*(1-b+b*float64(doc.length)/avgLength))
+
+				h := hits[key]
+				if h == nil {
+					h = &hit{matched: make(map[string]bool), words: make(map[string]bool)}
+					hits[key] = h
+				}
+				h.score += weight * idf * norm
+				h.matched[queryTerm] = true
+				h.words[term] = true
+			}
+		}
+	}
+
+	results := make([]models.SearchResult, 0, len(hits))
+	for key, h := range hits {
+		words := make([]string, 0, len(h.words))
+		for word := range h.words {
+			words = append(words, word)
+		}
+		sort.Strings(words)
+
+		coverage := float64(len(h.matched)) / float64(len(queryTerms))
+		results = append(results, models.SearchResult{
+			Item:       ix.docs[key].item,
+			Matches:    words,
+			MatchCount: len(h.matched),
+			Score:      h.score * coverage,
+		})
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Item.Published.After(results[j].Item.Published)
+	})
+	return results
+}
+
+// expand returns the indexed terms a query term matches, with their weight
+func (ix *Index) expand(queryTerm string) map[string]float64 {
+	terms := make(map[string]float64)
+	if _, ok := ix.postings[queryTerm]; ok {
+		terms[queryTerm] = 1
+	}
+	if utf8.RuneCountInString(queryTerm) >= minPrefixLength {
+		for term := range ix.postings {
+			if term != queryTerm && strings.HasPrefix(term, queryTerm) {
+				terms[term] = prefixWeight
+			}
+		}
+	}
+	return terms
+}
+
+func unique(terms []string) []string {
+	seen := make(map[string]bool, len(terms))
+	var out []string
+	for _, term := range terms {
+		if !seen[term] {
+			seen[term] = true
+			out = append(out, term)
+		}
+	}
+	return out
+}
+
+// Token is a word in a text and its byte offsets
+type Token struct {
+	Term       string // Lowercased word
+	Start, End int
+}
+
+// Tokens splits text into words: runs of letters and digits
+func Tokens(text string) []Token {
+	var tokens []Token
+	start := -1
+	for i, r := range text {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		if start >= 0 {
+			tokens = append(tokens, Token{Term: strings.ToLower(text[start:i]), Start: start, End: i})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		tokens = append(tokens, Token{Term: strings.ToLower(text[start:]), Start: start, End: len(text)})
+	}
+	return tokens
+}
+
+// Tokenize returns the lowercased words of text, ignoring HTML markup
+func Tokenize(text string) []string {
+	tokens := Tokens(PlainText(text))
+	terms := make([]string, len(tokens))
+	for i, token := range tokens {
+		terms[i] = token.Term
+	}
+	return terms
+}
+
+// PlainText removes HTML tags and decodes entities
+func PlainText(text string) string {
+	if !strings.ContainsAny(text, "<&") {
+		return text
+	}
+
+	var sb strings.Builder
+	inTag := false
+	for _, r := range text {
+		switch {
+		case r == '<':
+			inTag = true
+		case r == '>' && inTag:
+			inTag = false
+			sb.WriteByte(' ')
+		case !inTag:
+			sb.WriteRune(r)
+		}
+	}
+	return html.UnescapeString(sb.String())
+}
+
+// MatchSpans returns the byte ranges of the words in text that are among
+// the matched terms
+func MatchSpans(text string, matches []string) [][2]int {
+	matched := make(map[string]bool, len(matches))
+	for _, term := range matches {
+		matched[term] = true
+	}
+
+	var spans [][2]int
+	for _, token := range Tokens(text) {
+		if matched[token.Term] {
+			spans = append(spans, [2]int{token.Start, token.End})
+		}
+	}
+	return spans
+}
+
+// Snippet returns about width characters of the plain text of text around
+// the first matched word, on a single line
+func Snippet(text string, matches []string, width int) string {
+	text = strings.Join(strings.Fields(PlainText(text)), " ")
+	if utf8.RuneCountInString(text) <= width {
+		return text
+	}
+
+	start := 0
+	if spans := MatchSpans(text, matches); len(spans) > 0 {
+		// Show some context before the match
+		start = max(spans[0][0]-width/4, 0)
+		for start > 0 && !utf8.RuneStart(text[start]) {
+			start--
+		}
+	}
+	end := start
+	for count := 0; end < len(text) && count < width; count++ {
+		_, size := utf8.DecodeRuneInString(text[end:])
+		end += size
+	}
+
+	// Cut at word boundaries where possible
+	if start > 0 && text[start-1] != ' ' {
+		if i := strings.IndexByte(text[start:end], ' '); i >= 0 && i < width/4 {
+			start += i + 1
+		}
+	}
+	if end < len(text) && text[end] != ' ' {
+		if i := strings.LastIndexByte(text[start:end], ' '); i > 0 {
+			end = start + i
+		}
+	}
+
+	snippet := text[start:end]
+	if start > 0 {
+		snippet = "…" + snippet
+	}
+	if end < len(text) {
+		snippet += "…"
+	}
+	return snippet
+}
@@ -0,0 +1,193 @@
+package search
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/thedittmer/rss-reader/internal/models"
+)
+
+func testItem(id, title, description string) models.FeedItem {
+	return models.FeedItem{ID: id, Title: title, Description: description}
+}
+
+func TestSearchRanksTitleAboveDescription(t *testing.T) {
+	ix := NewIndex()
+	ix.Update([]models.FeedItem{
+		testItem("description", "Weekly notes", "A short update about kubernetes releases"),
+		testItem("title", "Kubernetes releases", "A short update about weekly notes"),
+		testItem("other", "Gardening", "Tomatoes and beans"),
+	})
+
+	results := ix.Search("kubernetes")
+	if len(results) != 2 {
+		t.Fatalf("got %d results, want 2", len(results))
+	}
+	if results[0].Item.ID != "title" || results[1].Item.ID != "description" {
+		t.Errorf("order = %s, %s; want title, description", results[0].Item.ID, results[1].Item.ID)
+	}
+	if results[0].Score <= results[1].Score {
+		t.Errorf("title score %v not above description score %v", results[0].Score, results[1].Score)
+	}
+}
+
+func TestSearchPrefersItemsMatchingMoreTerms(t *testing.T) {
+	ix := NewIndex()
+	ix.Update([]models.FeedItem{
+		testItem("one", "Go generics", ""),
+		testItem("both", "Go generics and iterators", ""),
+	})
+
+	results := ix.Search("generics iterators")
+	if len(results) != 2 || results[0].Item.ID != "both" {
+		t.Fatalf("results = %+v, want the item matching both terms first", results)
+	}
+	if results[0].MatchCount != 2 || results[1].MatchCount != 1 {
+		t.Errorf("match counts = %d, %d; want 2, 1", results[0].MatchCount, results[1].MatchCount)
+	}
+}
+
+func TestUpdateDropsStalePostings(t *testing.T) {
+	ix := NewIndex()
+	ix.Update([]models.FeedItem{
+		testItem("kept", "Rust compiler news", ""),
+		testItem("changed", "Python packaging", ""),
+		testItem("removed", "Haskell monads", ""),
+	})
+
+	ix.Update([]models.FeedItem{
+		testItem("kept", "Rust compiler news", ""),
+		testItem("changed", "Python typing", ""),
+	})
+
+	if ix.Len() != 2 {
+		t.Errorf("Len = %d, want 2", ix.Len())
+	}
+	for _, query := range []string{"haskell", "monads", "packaging"} {
+		if results := ix.Search(query); len(results) != 0 {
+			t.Errorf("Search(%q) = %d results, want none", query, len(results))
+		}
+	}
+	if results := ix.Search("typing"); len(results) != 1 || results[0].Item.ID != "changed" {
+		t.Errorf("Search(typing) = %+v, want the changed item", results)
+	}
+
+	// No posting may refer to a dropped item or to a term an item lost
+	for term, postings := range ix.postings {
+		if len(postings) == 0 {
+			t.Errorf("empty postings kept for %q", term)
+		}
+		for key := range postings {
+			doc, ok := ix.docs[key]
+			if !ok {
+				t.Errorf("posting for %q refers to dropped item %q", term, key)
+				continue
+			}
+			if _, ok := doc.terms[term]; !ok {
+				t.Errorf("posting for %q refers to %q, which no longer contains it", term, key)
+			}
+		}
+	}
+
+	length := 0
+	for _, doc := range ix.docs {
+		length += doc.length
+	}
+	if ix.totalLength != length {
+		t.Errorf("totalLength = %d, want %d", ix.totalLength, length)
+	}
+}
+
+func TestUpdateKeepsItemChanges(t *testing.T) {
+	ix := NewIndex()
+	item := testItem("a", "Release notes", "")
+	ix.Update([]models.FeedItem{item})
+
+	item.Sources = []string{"Feed A", "Feed B"}
+	ix.Update([]models.FeedItem{item})
+
+	results := ix.Search("release")
+	if len(results) != 1 || len(results[0].Item.Sources) != 2 {
+		t.Errorf("results = %+v, want the updated item", results)
+	}
+}
+
+func TestMatchSpansMultiByte(t *testing.T) {
+	tests := []struct {
+		text    string
+		matches []string
+		want    []string
+	}{
+		{"Café in München", []string{"münchen"}, []string{"München"}},
+		{"Ärger über Straßen", []string{"ärger", "straßen"}, []string{"Ärger", "Straßen"}},
+		{"東京 ニュース 東京", []string{"東京"}, []string{"東京", "東京"}},
+		{"naïve café", []string{"missing"}, nil},
+	}
+
+	for _, tt := range tests {
+		spans := MatchSpans(tt.text, tt.matches)
+		var got []string
+		for _, span := range spans {
+			if !utf8.ValidString(tt.text[span[0]:span[1]]) {
+				t.Errorf("MatchSpans(%q) span %v splits a character", tt.text, span)
+				continue
+			}
+			got = append(got, tt.text[span[0]:span[1]])
+		}
+		if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+			t.Errorf("MatchSpans(%q, %v) = %q, want %q", tt.text, tt.matches, got, tt.want)
+		}
+	}
+}
+
+func TestSnippet(t *testing.T) {
+	long := "The quick brown fox jumps over the lazy dog while the farmer watches " +
+		"from the porch and the cat sleeps in the warm afternoon sun near the barn"
+
+	tests := []struct {
+		name    string
+		text    string
+		matches []string
+		width   int
+		want    string
+	}{
+		{"short text unchanged", "Just <b>a</b>   few words", nil, 40, "Just a few words"},
+		{"no match cuts at end", long, nil, 30, "The quick brown fox jumps over…"},
+		{"match in the middle", long, []string{"porch"}, 30, "…the porch and the cat…"},
+		{"multi-byte text", strings.Repeat("über straße ", 10), []string{"missing"}, 20, "über straße über…"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Snippet(tt.text, tt.matches, tt.width)
+			if got != tt.want {
+				t.Errorf("Snippet = %q, want %q", got, tt.want)
+			}
+			if !utf8.ValidString(got) {
+				t.Errorf("Snippet %q is not valid UTF-8", got)
+			}
+		})
+	}
+}
+
+func TestSnippetCutsAtWordBoundaries(t *testing.T) {
+	text := strings.Repeat("lorem ipsum dolor sit amet ", 20) + "needle " +
+		strings.Repeat("consectetur adipiscing elit ", 20)
+	words := make(map[string]bool)
+	for _, word := range strings.Fields(text) {
+		words[word] = true
+	}
+
+	for _, width := range []int{20, 33, 50, 71} {
+		snippet := Snippet(text, []string{"needle"}, width)
+		if !strings.Contains(snippet, "needle") {
+			t.Errorf("width %d: snippet %q lacks the match", width, snippet)
+		}
+		for _, word := range strings.Fields(strings.Trim(snippet, "…")) {
+			if !words[word] {
+				t.Errorf("width %d: snippet %q contains partial word %q", width, snippet, word)
+			}
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/thedittmer/rss-reader/internal/models"
+)
+
+// SaveArticles persists the article cache so items survive restarts
+func (s *Storage) SaveArticles(items []models.FeedItem) error {
+	path := filepath.Join(s.dataDir, "articles.json")
+	tempPath := path + ".tmp"
+
+	data, err := json.MarshalIndent(items, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshaling articles: %w", err)
+	}
+
+	// Write to temporary file first
+	if err := os.WriteFile(tempPath, data, 0644); err != nil {
+		return fmt.Errorf("error writing temporary articles: %w", err)
+	}
+
+	// Rename temporary file to actual file (atomic operation)
+	if err := os.Rename(tempPath, path); err != nil {
+		return fmt.Errorf("error saving articles: %w", err)
+	}
+
+	return nil
+}
+
+// LoadArticles returns the cached articles, or an empty list if none are stored yet
+func (s *Storage) LoadArticles() ([]models.FeedItem, error) {
+	path := filepath.Join(s.dataDir, "articles.json")
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []models.FeedItem{}, nil
+		}
+		return nil, fmt.Errorf("error reading articles: %w", err)
+	}
+
+	var items []models.FeedItem
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, fmt.Errorf("error parsing articles: %w", err)
+	}
+
+	return items, nil
+}
+
+// MergeArticles merges freshly fetched items into the cached ones. Items are
+// matched by their key; fetched items replace the cached copy, new items are
+// appended, and cached items no longer present in a feed are kept.
+func MergeArticles(cached, fetched []models.FeedItem) []models.FeedItem {
+	merged := make([]models.FeedItem, len(cached), len(cached)+len(fetched))
+	copy(merged, cached)
+
+	index := make(map[string]int, len(merged))
+	for i, item := range merged {
+		index[item.Key()] = i
+	}
+
+	for _, item := range fetched {
+		key := item.Key()
+		if i, ok := index[key]; ok {
+			merged[i] = refreshItem(merged[i], item)
+			continue
+		}
+		index[key] = len(merged)
+		merged = append(merged, item)
+	}
+
+	return merged
+}
+
+// LimitArticles keeps at most limit(feedURL) items of each feed, dropping
+// the oldest; a limit of 0 keeps every item. The order of the items kept is
+// unchanged.
+func LimitArticles(items []models.FeedItem, limit func(feedURL string) int) []models.FeedItem {
+	byFeed := make(map[string][]int)
+	for i, item := range items {
+		byFeed[item.FeedURL] = append(byFeed[item.FeedURL], i)
+	}
+
+	drop := make(map[int]bool)
+	for feedURL, indexes := range byFeed {
+		n := limit(feedURL)
+		if n <= 0 || len(indexes) <= n {
+			continue
+		}
+		sort.SliceStable(indexes, func(i, j int) bool {
+			return items[indexes[i]].Published.After(items[indexes[j]].Published)
+		})
+		for _, i := range indexes[n:] {
+			drop[i] = true
+		}
+	}
+	if len(drop) == 0 {
+		return items
+	}
+
+	kept := make([]models.FeedItem, 0, len(items)-len(drop))
+	for i, item := range items {
+		if !drop[i] {
+			kept = append(kept, item)
+		}
+	}
+	return kept
+}
+
+// refreshItem returns the fetched copy of a cached item, keeping when it was
+// first seen and any date estimated at that time so dates stay stable
+func refreshItem(cached, fetched models.FeedItem) models.FeedItem {
+	if !cached.FirstSeen.IsZero() {
+		fetched.FirstSeen = cached.FirstSeen
+	}
+	if fetched.DateEstimated && cached.DateEstimated {
+		fetched.Published = cached.Published
+	}
+	return fetched
+}
@@ -0,0 +1,63 @@
+package storage
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/thedittmer/rss-reader/internal/models"
+)
+
+func TestLimitArticles(t *testing.T) {
+	day := func(n int) time.Time { return time.Date(2024, time.March, n, 0, 0, 0, 0, time.UTC) }
+	items := []models.FeedItem{
+		{ID: "a1", FeedURL: "a", Published: day(1)},
+		{ID: "b1", FeedURL: "b", Published: day(1)},
+		{ID: "a3", FeedURL: "a", Published: day(3)},
+		{ID: "a2", FeedURL: "a", Published: day(2)},
+		{ID: "b2", FeedURL: "b", Published: day(2)},
+		{ID: "c1", FeedURL: "c", Published: day(1)},
+	}
+	limits := map[string]int{"a": 2, "b": 5}
+
+	got := LimitArticles(items, func(feedURL string) int { return limits[feedURL] })
+
+	var ids []string
+	for _, item := range got {
+		ids = append(ids, item.ID)
+	}
+	want := []string{"b1", "a3", "a2", "b2", "c1"}
+	if !reflect.DeepEqual(ids, want) {
+		t.Errorf("kept %v, want %v", ids, want)
+	}
+}
+
+func TestRefreshCountsNewArticlesAtLimit(t *testing.T) {
+	day := func(n int) time.Time { return time.Date(2024, time.March, n, 0, 0, 0, 0, time.UTC) }
+	limit := func(string) int { return 2 }
+	refresh := func(cached, fetched []models.FeedItem) ([]models.FeedItem, []models.FeedItem) {
+		merged := MergeArticles(cached, fetched)
+		merged = LimitArticles(merged, limit)
+		return merged, models.Dedupe(merged)
+	}
+
+	cached, items := refresh(nil, []models.FeedItem{
+		{ID: "a1", FeedURL: "a", Link: "https://a.example/1", Published: day(1)},
+		{ID: "a2", FeedURL: "a", Link: "https://a.example/2", Published: day(2)},
+		{ID: "b1", FeedURL: "b", Link: "https://b.example/1", Published: day(1)},
+	})
+
+	// The feed is full, so the new article pushes out the oldest one and the
+	// list keeps its length; the copy in feed b adds no article either
+	_, after := refresh(cached, []models.FeedItem{
+		{ID: "a2", FeedURL: "a", Link: "https://a.example/2", Published: day(2)},
+		{ID: "a3", FeedURL: "a", Link: "https://a.example/3", Published: day(3)},
+		{ID: "b2", FeedURL: "b", Link: "https://a.example/2", Published: day(2)},
+	})
+	if len(after) != len(items) {
+		t.Fatalf("list has %d articles, want %d", len(after), len(items))
+	}
+	if got := models.CountNew(items, after); got != 1 {
+		t.Errorf("CountNew = %d, want 1", got)
+	}
+}
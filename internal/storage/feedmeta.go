@@ -0,0 +1,51 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/thedittmer/rss-reader/internal/models"
+)
+
+// SaveFeedMeta persists fetch metadata for all feeds, keyed by feed URL
+func (s *Storage) SaveFeedMeta(meta map[string]models.FeedMeta) error {
+	path := filepath.Join(s.dataDir, "feedmeta.json")
+	tempPath := path + ".tmp"
+
+	data, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshaling feed metadata: %w", err)
+	}
+
+	if err := os.WriteFile(tempPath, data, 0644); err != nil {
+		return fmt.Errorf("error writing temporary feed metadata: %w", err)
+	}
+
+	if err := os.Rename(tempPath, path); err != nil {
+		return fmt.Errorf("error saving feed metadata: %w", err)
+	}
+
+	return nil
+}
+
+// LoadFeedMeta returns the stored fetch metadata, or an empty map if none exists
+func (s *Storage) LoadFeedMeta() (map[string]models.FeedMeta, error) {
+	path := filepath.Join(s.dataDir, "feedmeta.json")
+	meta := make(map[string]models.FeedMeta)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return meta, nil
+		}
+		return nil, fmt.Errorf("error reading feed metadata: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return nil, fmt.Errorf("error parsing feed metadata: %w", err)
+	}
+
+	return meta, nil
+}
@@ -0,0 +1,112 @@
+package storage
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/thedittmer/rss-reader/internal/models"
+)
+
+// SaveFeeds persists the feed list and every feed's settings to feeds.json
+func (s *Storage) SaveFeeds(feeds []models.Feed) error {
+	path := filepath.Join(s.dataDir, "feeds.json")
+	tempPath := path + ".tmp"
+
+	if feeds == nil {
+		feeds = []models.Feed{}
+	}
+	data, err := json.MarshalIndent(feeds, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshaling feeds: %w", err)
+	}
+
+	// Write to temporary file first
+	if err := os.WriteFile(tempPath, data, 0644); err != nil {
+		return fmt.Errorf("error writing temporary feeds: %w", err)
+	}
+
+	// Rename temporary file to actual file (atomic operation)
+	if err := os.Rename(tempPath, path); err != nil {
+		return fmt.Errorf("error saving feeds: %w", err)
+	}
+
+	log.Printf("Feeds saved successfully to: %s", path)
+	return nil
+}
+
+// LoadFeeds reads feeds.json. Without it, the feeds of an older feeds.txt are
+// migrated, or the default feeds are created.
+func (s *Storage) LoadFeeds() ([]models.Feed, error) {
+	path := filepath.Join(s.dataDir, "feeds.json")
+
+	data, err := os.ReadFile(path)
+	if err == nil {
+		var feeds []models.Feed
+		if err := json.Unmarshal(data, &feeds); err != nil {
+			return nil, fmt.Errorf("error parsing feeds: %w", err)
+		}
+		log.Printf("Loaded %d feeds from: %s", len(feeds), path)
+		return feeds, nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("error reading feeds: %w", err)
+	}
+
+	textPath := filepath.Join(s.dataDir, "feeds.txt")
+	if _, err := os.Stat(textPath); err == nil {
+		feeds, err := loadFeedsText(textPath)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.SaveFeeds(feeds); err != nil {
+			return nil, fmt.Errorf("error migrating feeds.txt: %w", err)
+		}
+		log.Printf("Migrated %d feeds from %s", len(feeds), textPath)
+		return feeds, nil
+	}
+
+	// Less News stamps every item with the same time
+	lessNews := models.NewFeed("https://lessnews.dev/rss.xml")
+	lessNews.Aggregator = true
+
+	defaultFeeds := []models.Feed{
+		lessNews,
+		models.NewFeed("https://blog.golang.org/feed.atom"),
+		models.NewFeed("https://news.ycombinator.com/rss"),
+		models.NewFeed("https://dev.to/feed"),
+	}
+	if err := s.SaveFeeds(defaultFeeds); err != nil {
+		return nil, fmt.Errorf("error creating default feeds file: %w", err)
+	}
+	return defaultFeeds, nil
+}
+
+// loadFeedsText parses the plain-text feed list used before feeds.json: one
+// URL per line and # comments
+func loadFeedsText(path string) ([]models.Feed, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading feeds file: %w", err)
+	}
+
+	var feeds []models.Feed
+	scanner := bufio.NewScanner(strings.NewReader(string(data)))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		feeds = append(feeds, models.NewFeed(line))
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error parsing feeds file: %w", err)
+	}
+
+	return feeds, nil
+}
@@ -0,0 +1,42 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/thedittmer/rss-reader/internal/models"
+)
+
+func TestLoadFeedsMigratesFeedsText(t *testing.T) {
+	store := &Storage{dataDir: t.TempDir()}
+	text := "# My feeds\nhttps://one.example/rss\n\n  https://two.example/atom  \n"
+	if err := os.WriteFile(filepath.Join(store.dataDir, "feeds.txt"), []byte(text), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	feeds, err := store.LoadFeeds()
+	if err != nil {
+		t.Fatalf("LoadFeeds: %v", err)
+	}
+	want := []models.Feed{
+		models.NewFeed("https://one.example/rss"),
+		models.NewFeed("https://two.example/atom"),
+	}
+	if !reflect.DeepEqual(feeds, want) {
+		t.Fatalf("feeds = %+v, want %+v", feeds, want)
+	}
+
+	// feeds.json is written, so feeds.txt is no longer read
+	if err := os.WriteFile(filepath.Join(store.dataDir, "feeds.txt"), []byte("https://three.example/rss\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	feeds, err = store.LoadFeeds()
+	if err != nil {
+		t.Fatalf("second LoadFeeds: %v", err)
+	}
+	if !reflect.DeepEqual(feeds, want) {
+		t.Errorf("feeds after migration = %+v, want %+v", feeds, want)
+	}
+}
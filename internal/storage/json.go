@@ -1,13 +1,11 @@
 package storage
 
 import (
-	"bufio"
 	"encoding/json"
 	"fmt"
 	"log"
 	"os"
 	"path/filepath"
-	"strings"
 	"time"
 
 	"github.com/thedittmer/rss-reader/internal/models"
@@ -30,10 +28,15 @@ func NewStorage() (*Storage, error) {
 		return nil, err
 	}
 
-	fmt.Printf("Using storage directory: %s\n", dataDir)
+	log.Printf("Using storage directory: %s", dataDir)
 	return &Storage{dataDir: dataDir}, nil
 }
 
+// ExportDir is where file exports are written
+func (s *Storage) ExportDir() string {
+	return filepath.Join(s.dataDir, "exports")
+}
+
 func (s *Storage) SaveProfile(profile *models.UserProfile) error {
 	log.Printf("Saving profile with %d interests", len(profile.Interests))
 	path := filepath.Join(s.dataDir, "profile.json")
@@ -58,7 +61,7 @@ func (s *Storage) SaveProfile(profile *models.UserProfile) error {
 		return fmt.Errorf("error saving profile: %w", err)
 	}
 
-	fmt.Printf("Profile saved successfully with %d interests\n", len(profile.Interests))
+	log.Printf("Profile saved successfully with %d interests", len(profile.Interests))
 	return nil
 }
 
@@ -96,63 +99,3 @@ func (s *Storage) LoadProfile() (*models.UserProfile, error) {
 
 	return profile, nil
 }
-
-func (s *Storage) SaveFeeds(feeds []string) error {
-	path := filepath.Join(s.dataDir, "feeds.txt")
-
-	// Create the content with comments
-	content := "# RSS Feed URLs (one per line)\n" +
-		"# Lines starting with # are comments\n" +
-		"# Example: https://example.com/feed.xml\n\n"
-
-	for _, feed := range feeds {
-		content += feed + "\n"
-	}
-
-	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
-		return fmt.Errorf("error saving feeds: %w", err)
-	}
-
-	fmt.Printf("Feeds saved successfully to: %s\n", path)
-	return nil
-}
-
-func (s *Storage) LoadFeeds() ([]string, error) {
-	path := filepath.Join(s.dataDir, "feeds.txt")
-
-	// Check if file exists, if not create with default feeds
-	if _, err := os.Stat(path); os.IsNotExist(err) {
-		defaultFeeds := []string{
-			"https://lessnews.dev/rss.xml",
-			"https://blog.golang.org/feed.atom",
-			"https://news.ycombinator.com/rss",
-			"https://dev.to/feed",
-		}
-		if err := s.SaveFeeds(defaultFeeds); err != nil {
-			return nil, fmt.Errorf("error creating default feeds file: %w", err)
-		}
-		return defaultFeeds, nil
-	}
-
-	// Read and parse the feeds file
-	data, err := os.ReadFile(path)
-	if err != nil {
-		return nil, fmt.Errorf("error reading feeds file: %w", err)
-	}
-
-	var feeds []string
-	scanner := bufio.NewScanner(strings.NewReader(string(data)))
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-		if line != "" && !strings.HasPrefix(line, "#") {
-			feeds = append(feeds, line)
-		}
-	}
-
-	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("error parsing feeds file: %w", err)
-	}
-
-	fmt.Printf("Loaded %d feeds from: %s\n", len(feeds), path)
-	return feeds, nil
-}
@@ -0,0 +1,269 @@
+package storage
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/sheets/v4"
+)
+
+// loginTimeout is how long the sign-in flow waits for the browser
+const loginTimeout = 5 * time.Minute
+
+// sheetsScopes are requested for both service accounts and users
+var sheetsScopes = []string{
+	sheets.SpreadsheetsScope,
+	drive.DriveScope,
+	drive.DriveFileScope,
+}
+
+// credentialsKind tells service account keys from OAuth client IDs. Only
+// desktop app clients are accepted, since sign-in redirects to a server on
+// the loopback interface.
+func credentialsKind(credentials []byte) (string, error) {
+	var key struct {
+		Type      string          `json:"type"`
+		Installed json.RawMessage `json:"installed"`
+		Web       json.RawMessage `json:"web"`
+	}
+	if err := json.Unmarshal(credentials, &key); err != nil {
+		return "", fmt.Errorf("unable to parse credentials: %v", err)
+	}
+
+	switch {
+	case key.Type == "service_account":
+		return "service_account", nil
+	case key.Installed != nil:
+		return "oauth_client", nil
+	case key.Web != nil:
+		return "", fmt.Errorf("OAuth client IDs for web applications are not supported: " +
+			"create an OAuth client ID for a desktop app, which can sign in through this computer")
+	default:
+		return "", fmt.Errorf("unsupported credentials file: expected a service account key " +
+			"or an OAuth client ID for a desktop app")
+	}
+}
+
+// userClient returns a client acting as the user who signed in. The token is
+// kept in the token file and refreshed as needed; without one, the user is
+// asked to sign in first.
+func userClient(credentials []byte, sheetsConfig *SheetsConfig) (*http.Client, error) {
+	oauthConfig, err := google.ConfigFromJSON(credentials, sheetsScopes...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse OAuth client ID: %v", err)
+	}
+
+	token, err := loadToken(sheetsConfig.TokenFile)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return nil, err
+		}
+		token, err = loginUser(oauthConfig, sheetsConfig)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ctx := context.Background()
+	source := &savingTokenSource{
+		source: oauthConfig.TokenSource(ctx, token),
+		path:   sheetsConfig.TokenFile,
+		last:   token.AccessToken,
+	}
+	return oauth2.NewClient(ctx, source), nil
+}
+
+// LoginSheets signs the user in again with an OAuth client ID, replacing
+// the saved token. Service account keys need no sign-in.
+func (s *Storage) LoginSheets(sheetsConfig *SheetsConfig) error {
+	credentials, err := readCredentials(sheetsConfig)
+	if err != nil {
+		return err
+	}
+	kind, err := credentialsKind(credentials)
+	if err != nil {
+		return err
+	}
+	if kind != "oauth_client" {
+		return fmt.Errorf("%s is a service account key, which needs no sign-in", sheetsConfig.CredentialsFile)
+	}
+
+	oauthConfig, err := google.ConfigFromJSON(credentials, sheetsScopes...)
+	if err != nil {
+		return fmt.Errorf("unable to parse OAuth client ID: %v", err)
+	}
+	_, err = loginUser(oauthConfig, sheetsConfig)
+	return err
+}
+
+// loginUser runs the installed-app flow: the consent page redirects to a
+// server on the loopback interface, which receives the authorization code.
+// The token is saved to the token file.
+func loginUser(oauthConfig *oauth2.Config, sheetsConfig *SheetsConfig) (*oauth2.Token, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("unable to start sign-in callback server: %v", err)
+	}
+	defer listener.Close()
+
+	config := *oauthConfig
+	config.RedirectURL = fmt.Sprintf("http://%s/", listener.Addr())
+
+	state, err := randomState()
+	if err != nil {
+		return nil, err
+	}
+	verifier := oauth2.GenerateVerifier()
+
+	type callback struct {
+		code string
+		err  error
+	}
+	done := make(chan callback, 1)
+	server := &http.Server{
+		ReadHeaderTimeout: 10 * time.Second,
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			query := r.URL.Query()
+			if query.Get("state") != state {
+				http.Error(w, "Invalid sign-in request", http.StatusBadRequest)
+				return
+			}
+
+			result := callback{code: query.Get("code")}
+			message := "Signed in. You can close this window and return to RSS Reader."
+			if reason := query.Get("error"); reason != "" || result.code == "" {
+				result.err = fmt.Errorf("sign-in was not completed: %s", reason)
+				message = "Sign-in was not completed: " + reason
+			}
+			fmt.Fprintf(w, "<!DOCTYPE html><title>RSS Reader</title><p>%s</p>", html.EscapeString(message))
+
+			select {
+			case done <- result:
+			default:
+			}
+		}),
+	}
+	go server.Serve(listener)
+	defer server.Close()
+
+	authURL := config.AuthCodeURL(state,
+		oauth2.AccessTypeOffline,
+		// Forcing consent makes Google return a refresh token every time
+		oauth2.ApprovalForce,
+		oauth2.S256ChallengeOption(verifier),
+	)
+	if sheetsConfig.OpenAuthURL != nil {
+		sheetsConfig.OpenAuthURL(authURL)
+	} else {
+		log.Printf("Sign in to Google to allow Sheets exports: %s", authURL)
+	}
+
+	var result callback
+	select {
+	case result = <-done:
+	case <-time.After(loginTimeout):
+		return nil, fmt.Errorf("timed out waiting for Google sign-in")
+	}
+	if result.err != nil {
+		return nil, result.err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	token, err := config.Exchange(ctx, result.code, oauth2.VerifierOption(verifier))
+	if err != nil {
+		return nil, fmt.Errorf("unable to complete sign-in: %v", err)
+	}
+
+	if err := saveToken(sheetsConfig.TokenFile, token); err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+func randomState() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("error generating sign-in state: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// savingTokenSource writes refreshed tokens back to the token file
+type savingTokenSource struct {
+	source oauth2.TokenSource
+	path   string
+
+	mu   sync.Mutex
+	last string // Access token last saved
+}
+
+func (s *savingTokenSource) Token() (*oauth2.Token, error) {
+	token, err := s.source.Token()
+	if err != nil {
+		var retrieveErr *oauth2.RetrieveError
+		if errors.As(err, &retrieveErr) {
+			return nil, fmt.Errorf("saved Google sign-in is no longer valid; run \"rss-reader sheets login\": %w", err)
+		}
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if token.AccessToken != s.last {
+		if err := saveToken(s.path, token); err != nil {
+			log.Printf("Error saving refreshed token: %v", err)
+		}
+		s.last = token.AccessToken
+	}
+	return token, nil
+}
+
+func loadToken(path string) (*oauth2.Token, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var token oauth2.Token
+	if err := json.Unmarshal(data, &token); err != nil {
+		return nil, fmt.Errorf("error parsing token file: %w", err)
+	}
+	return &token, nil
+}
+
+// saveToken writes the token readable by the current user only
+func saveToken(path string, token *oauth2.Token) error {
+	tempPath := path + ".tmp"
+
+	data, err := json.MarshalIndent(token, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshaling token: %w", err)
+	}
+
+	// Write to temporary file first
+	if err := os.WriteFile(tempPath, data, 0600); err != nil {
+		return fmt.Errorf("error writing temporary token: %w", err)
+	}
+
+	// Rename temporary file to actual file (atomic operation)
+	if err := os.Rename(tempPath, path); err != nil {
+		return fmt.Errorf("error saving token: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,255 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func TestCredentialsKind(t *testing.T) {
+	tests := []struct {
+		name        string
+		credentials string
+		want        string
+		wantErr     string
+	}{
+		{"service account", `{"type": "service_account", "client_email": "bot@example.iam.gserviceaccount.com"}`, "service_account", ""},
+		{"desktop client", `{"installed": {"client_id": "id", "client_secret": "secret"}}`, "oauth_client", ""},
+		{"web client", `{"web": {"client_id": "id", "client_secret": "secret"}}`, "", "web applications are not supported"},
+		{"unknown type", `{"type": "authorized_user"}`, "", "unsupported credentials file"},
+		{"not JSON", `client_id=id`, "", "unable to parse credentials"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := credentialsKind([]byte(tt.credentials))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("credentialsKind: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("kind = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// fakeTokenEndpoint issues tokens for authorization codes and refresh
+// tokens, numbering the access tokens it hands out
+type fakeTokenEndpoint struct {
+	mu       sync.Mutex
+	issued   int
+	requests []url.Values
+	fail     bool // Reject every request as Google does for revoked tokens
+}
+
+func (f *fakeTokenEndpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests = append(f.requests, r.PostForm)
+
+	w.Header().Set("Content-Type", "application/json")
+	if f.fail {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+		return
+	}
+	f.issued++
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"access_token":  fmt.Sprintf("access-%d", f.issued),
+		"token_type":    "Bearer",
+		"refresh_token": "refresh",
+		"expires_in":    3600,
+	})
+}
+
+func newTestOAuthConfig(t *testing.T, endpoint *fakeTokenEndpoint) *oauth2.Config {
+	t.Helper()
+	server := httptest.NewServer(endpoint)
+	t.Cleanup(server.Close)
+
+	return &oauth2.Config{
+		ClientID:     "client",
+		ClientSecret: "secret",
+		Endpoint: oauth2.Endpoint{
+			AuthURL:   "https://accounts.example/auth",
+			TokenURL:  server.URL,
+			AuthStyle: oauth2.AuthStyleInParams,
+		},
+		Scopes: sheetsScopes,
+	}
+}
+
+func TestSavingTokenSourceSavesRefreshedToken(t *testing.T) {
+	endpoint := &fakeTokenEndpoint{}
+	oauthConfig := newTestOAuthConfig(t, endpoint)
+	path := filepath.Join(t.TempDir(), "token.json")
+
+	expired := &oauth2.Token{
+		AccessToken:  "stale",
+		RefreshToken: "refresh",
+		Expiry:       time.Now().Add(-time.Hour),
+	}
+	source := &savingTokenSource{
+		source: oauthConfig.TokenSource(context.Background(), expired),
+		path:   path,
+		last:   expired.AccessToken,
+	}
+
+	token, err := source.Token()
+	if err != nil {
+		t.Fatalf("Token: %v", err)
+	}
+	if token.AccessToken != "access-1" {
+		t.Fatalf("access token = %q, want the refreshed one", token.AccessToken)
+	}
+
+	saved, err := loadToken(path)
+	if err != nil {
+		t.Fatalf("refreshed token not saved: %v", err)
+	}
+	if saved.AccessToken != "access-1" || saved.RefreshToken != "refresh" {
+		t.Errorf("saved token = %+v, want the refreshed token", saved)
+	}
+
+	// A still valid token is neither refreshed nor written again
+	if err := saveToken(path, &oauth2.Token{AccessToken: "marker"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := source.Token(); err != nil {
+		t.Fatalf("second Token: %v", err)
+	}
+	if saved, _ := loadToken(path); saved.AccessToken != "marker" {
+		t.Errorf("token file rewritten with %q although the token did not change", saved.AccessToken)
+	}
+	if len(endpoint.requests) != 1 {
+		t.Errorf("token endpoint called %d times, want 1", len(endpoint.requests))
+	}
+}
+
+func TestSavingTokenSourceRevokedToken(t *testing.T) {
+	endpoint := &fakeTokenEndpoint{fail: true}
+	oauthConfig := newTestOAuthConfig(t, endpoint)
+	expired := &oauth2.Token{AccessToken: "stale", RefreshToken: "revoked", Expiry: time.Now().Add(-time.Hour)}
+	source := &savingTokenSource{
+		source: oauthConfig.TokenSource(context.Background(), expired),
+		path:   filepath.Join(t.TempDir(), "token.json"),
+		last:   expired.AccessToken,
+	}
+
+	_, err := source.Token()
+	if err == nil || !strings.Contains(err.Error(), "rss-reader sheets login") {
+		t.Errorf("error = %v, want advice to sign in again", err)
+	}
+}
+
+func TestLoginUser(t *testing.T) {
+	endpoint := &fakeTokenEndpoint{}
+	oauthConfig := newTestOAuthConfig(t, endpoint)
+	sheetsConfig := NewSheetsConfig(t.TempDir())
+
+	// The browser first presents a forged callback, then the real one
+	var forgedStatus int
+	browserDone := make(chan struct{})
+	sheetsConfig.OpenAuthURL = func(authURL string) {
+		go func() {
+			defer close(browserDone)
+			parsed, err := url.Parse(authURL)
+			if err != nil {
+				t.Errorf("invalid auth URL: %v", err)
+				return
+			}
+			query := parsed.Query()
+			redirect := query.Get("redirect_uri")
+			if !strings.HasPrefix(redirect, "http://127.0.0.1:") {
+				t.Errorf("redirect_uri = %q, want the loopback server", redirect)
+				return
+			}
+			if query.Get("code_challenge") == "" || query.Get("access_type") != "offline" {
+				t.Errorf("auth URL %s lacks PKCE or offline access", authURL)
+			}
+
+			forged, err := http.Get(redirect + "?state=forged&code=stolen")
+			if err != nil {
+				t.Errorf("forged callback: %v", err)
+				return
+			}
+			forged.Body.Close()
+			forgedStatus = forged.StatusCode
+
+			callback := redirect + "?" + url.Values{"state": {query.Get("state")}, "code": {"good-code"}}.Encode()
+			if response, err := http.Get(callback); err == nil {
+				response.Body.Close()
+			}
+		}()
+	}
+
+	token, err := loginUser(oauthConfig, sheetsConfig)
+	<-browserDone
+	if err != nil {
+		t.Fatalf("loginUser: %v", err)
+	}
+
+	if forgedStatus != http.StatusBadRequest {
+		t.Errorf("callback with wrong state answered %d, want 400", forgedStatus)
+	}
+	if len(endpoint.requests) != 1 {
+		t.Fatalf("token endpoint called %d times, want once", len(endpoint.requests))
+	}
+	if request := endpoint.requests[0]; request.Get("code") != "good-code" || request.Get("code_verifier") == "" {
+		t.Errorf("token request = %v, want the real code and a PKCE verifier", request)
+	}
+
+	saved, err := loadToken(sheetsConfig.TokenFile)
+	if err != nil {
+		t.Fatalf("token not saved: %v", err)
+	}
+	if saved.AccessToken != token.AccessToken || saved.RefreshToken != "refresh" {
+		t.Errorf("saved token = %+v, want %+v", saved, token)
+	}
+}
+
+func TestLoginUserDenied(t *testing.T) {
+	endpoint := &fakeTokenEndpoint{}
+	oauthConfig := newTestOAuthConfig(t, endpoint)
+	sheetsConfig := NewSheetsConfig(t.TempDir())
+	sheetsConfig.OpenAuthURL = func(authURL string) {
+		parsed, _ := url.Parse(authURL)
+		query := parsed.Query()
+		callback := query.Get("redirect_uri") + "?" +
+			url.Values{"state": {query.Get("state")}, "error": {"access_denied"}}.Encode()
+		go func() {
+			if response, err := http.Get(callback); err == nil {
+				response.Body.Close()
+			}
+		}()
+	}
+
+	_, err := loginUser(oauthConfig, sheetsConfig)
+	if err == nil || !strings.Contains(err.Error(), "access_denied") {
+		t.Errorf("error = %v, want the sign-in to fail with access_denied", err)
+	}
+	if len(endpoint.requests) != 0 {
+		t.Error("token requested although sign-in was denied")
+	}
+}
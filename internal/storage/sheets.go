@@ -3,7 +3,10 @@ package storage
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
@@ -11,8 +14,11 @@ import (
 
 	"golang.org/x/oauth2/google"
 	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/option"
 	"google.golang.org/api/sheets/v4"
 
+	"github.com/thedittmer/rss-reader/internal/export"
 	"github.com/thedittmer/rss-reader/internal/models"
 )
 
@@ -20,36 +26,196 @@ type SheetsConfig struct {
 	CredentialsFile string
 	TokenFile       string
 	SpreadsheetID   string
+
+	Append    bool // Add rows to SpreadsheetID, skipping links already present
+	DatedTabs bool // Write to a tab named after the day of the export
+
+	// FolderID is the Drive folder in which the "RSS Reader" folder holding
+	// exported spreadsheets is kept; RootFolder is the account's My Drive
+	FolderID string
+
+	// HTTPClient and the endpoints replace the client authorized from
+	// CredentialsFile and the Google API base URLs, e.g. to use a local
+	// server. Empty endpoints use Google's.
+	HTTPClient     *http.Client
+	SheetsEndpoint string // Base URL of the Sheets API, e.g. "https://sheets.googleapis.com/"
+	DriveEndpoint  string // Base URL of the Drive API, e.g. "https://www.googleapis.com/drive/v3/"
+
+	// OpenAuthURL shows the Google sign-in page when an OAuth client ID is
+	// used and there is no saved token; the URL is logged when nil
+	OpenAuthURL func(url string)
 }
 
+// RootFolder is the Drive alias for the root of My Drive
+const RootFolder = "root"
+
 func NewSheetsConfig(dataDir string) *SheetsConfig {
 	return &SheetsConfig{
 		CredentialsFile: filepath.Join(dataDir, "credentials.json"),
 		TokenFile:       filepath.Join(dataDir, "token.json"),
+		FolderID:        RootFolder,
+	}
+}
+
+// SheetsConfig returns the Sheets settings for the data directory, exporting
+// to the given Drive folder ID or folder URL
+func (s *Storage) SheetsConfig(folder string) (*SheetsConfig, error) {
+	folderID, err := ParseFolderID(folder)
+	if err != nil {
+		return nil, err
+	}
+	sheetsConfig := NewSheetsConfig(s.dataDir)
+	sheetsConfig.FolderID = folderID
+	return sheetsConfig, nil
+}
+
+// ParseFolderID accepts a Drive folder ID or a folder URL such as
+// https://drive.google.com/drive/folders/ID. Empty selects My Drive.
+func ParseFolderID(folder string) (string, error) {
+	folder = strings.TrimSpace(folder)
+	if folder == "" || folder == RootFolder {
+		return RootFolder, nil
+	}
+
+	if strings.Contains(folder, "://") {
+		u, err := url.Parse(folder)
+		if err != nil {
+			return "", fmt.Errorf("invalid Drive folder URL %q: %w", folder, err)
+		}
+		_, id, found := strings.Cut(u.Path, "/folders/")
+		id, _, _ = strings.Cut(id, "/")
+		if !found || id == "" {
+			return "", fmt.Errorf("%q is not a Drive folder URL (expected .../drive/folders/ID)", folder)
+		}
+		return id, nil
+	}
+
+	for _, r := range folder {
+		if !(r == '-' || r == '_' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z') {
+			return "", fmt.Errorf("%q is not a Drive folder ID", folder)
+		}
+	}
+	return folder, nil
+}
+
+// newSheetsServices creates Sheets and Drive clients, authorized from the
+// credentials file unless an HTTP client is configured
+func newSheetsServices(sheetsConfig *SheetsConfig) (*sheets.Service, *drive.Service, error) {
+	client := sheetsConfig.HTTPClient
+	if client == nil {
+		var err error
+		client, err = authorizedClient(sheetsConfig)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	ctx := context.Background()
+	sheetsOptions := []option.ClientOption{option.WithHTTPClient(client)}
+	if sheetsConfig.SheetsEndpoint != "" {
+		sheetsOptions = append(sheetsOptions, option.WithEndpoint(sheetsConfig.SheetsEndpoint))
+	}
+	sheetsService, err := sheets.NewService(ctx, sheetsOptions...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to create sheets client: %v", err)
 	}
+
+	driveOptions := []option.ClientOption{option.WithHTTPClient(client)}
+	if sheetsConfig.DriveEndpoint != "" {
+		driveOptions = append(driveOptions, option.WithEndpoint(sheetsConfig.DriveEndpoint))
+	}
+	driveService, err := drive.NewService(ctx, driveOptions...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to create drive client: %v", err)
+	}
+
+	return sheetsService, driveService, nil
 }
 
-func (s *Storage) getOrCreateRSSFolder(driveService *drive.Service) (string, error) {
-	// Use the specific Sales shared drive folder ID
-	companyFolderID := "17sE3dh1ujQtuecSLdutpTGsinpudD3Qb"
+// authorizedClient returns an HTTP client authorized by the credentials
+// file, which holds either a service account key or an OAuth client ID
+func authorizedClient(sheetsConfig *SheetsConfig) (*http.Client, error) {
+	credentials, err := readCredentials(sheetsConfig)
+	if err != nil {
+		return nil, err
+	}
+	kind, err := credentialsKind(credentials)
+	if err != nil {
+		return nil, err
+	}
+	if kind == "oauth_client" {
+		return userClient(credentials, sheetsConfig)
+	}
 
-	// First, try to access the company folder to verify permissions
-	_, err := driveService.Files.Get(companyFolderID).Fields("id").SupportsAllDrives(true).Do()
+	// Configure the Google Sheets client with additional scopes
+	oauthConfig, err := google.JWTConfigFromJSON(credentials, sheetsScopes...)
 	if err != nil {
-		// Clean up any potential trailing periods in the error message
-		cleanFolderID := strings.TrimRight(companyFolderID, ".")
-		return "", fmt.Errorf("service account cannot access the shared folder. Please follow these steps:\n"+
-			"1. Open this folder: https://drive.google.com/drive/folders/%s\n"+
-			"2. Click the 'Share' button\n"+
-			"3. Add this email as a Content Manager: %s\n"+
-			"4. Click 'Share'\n"+
-			"5. Try exporting again\n"+
-			"Error: %v", cleanFolderID, s.getServiceAccountEmail(), err)
-	}
-
-	// Search for RSS Reader folder within the shared folder
-	query := fmt.Sprintf("name = 'RSS Reader' and mimeType = 'application/vnd.google-apps.folder' and trashed = false and '%s' in parents", companyFolderID)
-	files, err := driveService.Files.List().Q(query).Spaces("drive").SupportsAllDrives(true).Do()
+		return nil, fmt.Errorf("unable to parse credentials: %v", err)
+	}
+
+	return oauthConfig.Client(context.Background()), nil
+}
+
+func readCredentials(sheetsConfig *SheetsConfig) ([]byte, error) {
+	credentials, err := os.ReadFile(sheetsConfig.CredentialsFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("Google Sheets export is not set up: %s not found.\n"+
+				"Save a service account key or an OAuth client ID for a desktop app there",
+				sheetsConfig.CredentialsFile)
+		}
+		return nil, fmt.Errorf("unable to read credentials file: %v", err)
+	}
+	return credentials, nil
+}
+
+// CheckSheetsSetup verifies that the credentials can be used and that the
+// configured folder is reachable, without creating anything
+func (s *Storage) CheckSheetsSetup(sheetsConfig *SheetsConfig) error {
+	_, driveService, err := newSheetsServices(sheetsConfig)
+	if err != nil {
+		return err
+	}
+	return checkFolderAccess(driveService, sheetsConfig)
+}
+
+// checkFolderAccess returns setup instructions when the configured folder
+// cannot be accessed
+func checkFolderAccess(driveService *drive.Service, sheetsConfig *SheetsConfig) error {
+	_, err := driveService.Files.Get(sheetsConfig.FolderID).Fields("id").SupportsAllDrives(true).Do()
+	if err == nil {
+		return nil
+	}
+
+	if sheetsConfig.FolderID == RootFolder {
+		return fmt.Errorf("unable to access My Drive with %s: %v", sheetsConfig.CredentialsFile, err)
+	}
+
+	credentials, _ := os.ReadFile(sheetsConfig.CredentialsFile)
+	if kind, _ := credentialsKind(credentials); kind != "service_account" {
+		return fmt.Errorf("cannot access the export folder https://drive.google.com/drive/folders/%s.\n"+
+			"Make sure the Google account you signed in with can edit it, or run \"rss-reader sheets login\" "+
+			"to sign in with another account.\n"+
+			"Or set sheets.folder in config.json to another folder, or leave it empty to use My Drive.\n"+
+			"Error: %v", sheetsConfig.FolderID, err)
+	}
+
+	return fmt.Errorf("cannot access the export folder. Please follow these steps:\n"+
+		"1. Open this folder: https://drive.google.com/drive/folders/%s\n"+
+		"2. Click the 'Share' button\n"+
+		"3. Add this email as a Content Manager: %s\n"+
+		"4. Click 'Share'\n"+
+		"5. Try exporting again\n"+
+		"Or set sheets.folder in config.json to another folder, or leave it empty to use My Drive.\n"+
+		"Error: %v", sheetsConfig.FolderID, serviceAccountEmail(credentials), err)
+}
+
+// getOrCreateRSSFolder returns the "RSS Reader" folder inside the configured
+// folder, creating it on first export
+func (s *Storage) getOrCreateRSSFolder(driveService *drive.Service, sheetsConfig *SheetsConfig) (string, error) {
+	query := fmt.Sprintf("name = 'RSS Reader' and mimeType = 'application/vnd.google-apps.folder' and trashed = false and '%s' in parents", sheetsConfig.FolderID)
+	files, err := driveService.Files.List().Q(query).Spaces("drive").
+		SupportsAllDrives(true).IncludeItemsFromAllDrives(true).Do()
 	if err != nil {
 		return "", fmt.Errorf("unable to search for RSS Reader folder: %v", err)
 	}
@@ -63,7 +229,7 @@ func (s *Storage) getOrCreateRSSFolder(driveService *drive.Service) (string, err
 	folder := &drive.File{
 		Name:     "RSS Reader",
 		MimeType: "application/vnd.google-apps.folder",
-		Parents:  []string{companyFolderID},
+		Parents:  []string{sheetsConfig.FolderID},
 	}
 
 	folder, err = driveService.Files.Create(folder).Fields("id").SupportsAllDrives(true).Do()
@@ -74,41 +240,19 @@ func (s *Storage) getOrCreateRSSFolder(driveService *drive.Service) (string, err
 	return folder.Id, nil
 }
 
-// Add helper function to get service account email
-func (s *Storage) getServiceAccountEmail() string {
-	credentials, err := os.ReadFile(filepath.Join(s.dataDir, "credentials.json"))
-	if err != nil {
-		return "unknown"
-	}
-
+// serviceAccountEmail returns the client email of a service account key
+func serviceAccountEmail(credentials []byte) string {
 	var creds struct {
 		ClientEmail string `json:"client_email"`
 	}
-	if err := json.Unmarshal(credentials, &creds); err != nil {
+	if err := json.Unmarshal(credentials, &creds); err != nil || creds.ClientEmail == "" {
 		return "unknown"
 	}
 
 	return creds.ClientEmail
 }
 
-func (s *Storage) createNewSpreadsheet(sheetsService *sheets.Service, driveService *drive.Service, sheetsConfig *SheetsConfig) (string, error) {
-	// Use the specific Sales shared drive folder ID
-	companyFolderID := "17sE3dh1ujQtuecSLdutpTGsinpudD3Qb"
-
-	// First, try to access the company folder to verify permissions
-	_, err := driveService.Files.Get(companyFolderID).Fields("id").SupportsAllDrives(true).Do()
-	if err != nil {
-		// Clean up any potential trailing periods in the error message
-		cleanFolderID := strings.TrimRight(companyFolderID, ".")
-		return "", fmt.Errorf("service account cannot access the shared folder. Please follow these steps:\n"+
-			"1. Open this folder: https://drive.google.com/drive/folders/%s\n"+
-			"2. Click the 'Share' button\n"+
-			"3. Add this email as a Content Manager: %s\n"+
-			"4. Click 'Share'\n"+
-			"5. Try exporting again\n"+
-			"Error: %v", cleanFolderID, s.getServiceAccountEmail(), err)
-	}
-
+func (s *Storage) createNewSpreadsheet(sheetsService *sheets.Service, driveService *drive.Service, folderID, tab string) (string, error) {
 	// Generate a unique filename with timestamp
 	timestamp := time.Now().Format("2006-01-02-15-04-05")
 	spreadsheetTitle := fmt.Sprintf("RSS Reader Articles - %s", timestamp)
@@ -121,49 +265,29 @@ func (s *Storage) createNewSpreadsheet(sheetsService *sheets.Service, driveServi
 		Sheets: []*sheets.Sheet{
 			{
 				Properties: &sheets.SheetProperties{
-					Title: "Sheet1",
+					Title: tab,
 				},
 			},
 		},
 	}
 
-	spreadsheet, err = sheetsService.Spreadsheets.Create(spreadsheet).Do()
+	spreadsheet, err := sheetsService.Spreadsheets.Create(spreadsheet).Do()
 	if err != nil {
 		return "", fmt.Errorf("unable to create spreadsheet: %v", err)
 	}
 
-	// Move spreadsheet to the shared folder
-	_, err = driveService.Files.Update(spreadsheet.SpreadsheetId, nil).AddParents(companyFolderID).Fields("id, parents").SupportsAllDrives(true).Do()
+	// New spreadsheets land in My Drive; a file may only have one parent,
+	// so the current one is replaced by the export folder
+	file, err := driveService.Files.Get(spreadsheet.SpreadsheetId).Fields("parents").SupportsAllDrives(true).Do()
 	if err != nil {
-		return "", fmt.Errorf("unable to move spreadsheet to folder: %v", err)
+		return "", fmt.Errorf("unable to look up spreadsheet location: %v", err)
 	}
-
-	// Get service account email from credentials
-	credentials, err := os.ReadFile(sheetsConfig.CredentialsFile)
-	if err != nil {
-		return "", fmt.Errorf("unable to read credentials file: %v", err)
-	}
-
-	var creds struct {
-		ClientEmail string `json:"client_email"`
-	}
-	if err := json.Unmarshal(credentials, &creds); err != nil {
-		return "", fmt.Errorf("unable to parse credentials: %v", err)
-	}
-
-	// Share with the service account
-	permission := &drive.Permission{
-		Type:         "user",
-		Role:         "writer",
-		EmailAddress: creds.ClientEmail,
-	}
-
-	// Try to share with the service account, but don't fail if it doesn't work
-	// since the service account already has access through the shared drive
-	_, err = driveService.Permissions.Create(spreadsheet.SpreadsheetId, permission).SupportsAllDrives(true).Do()
+	_, err = driveService.Files.Update(spreadsheet.SpreadsheetId, nil).
+		AddParents(folderID).
+		RemoveParents(strings.Join(file.Parents, ",")).
+		Fields("id, parents").SupportsAllDrives(true).Do()
 	if err != nil {
-		// Log the error but don't fail the operation
-		fmt.Printf("Note: Could not explicitly share with service account (this is usually fine): %v\n", err)
+		return "", fmt.Errorf("unable to move spreadsheet to folder: %v", err)
 	}
 
 	return spreadsheet.SpreadsheetId, nil
@@ -173,66 +297,93 @@ func (s *Storage) createNewSpreadsheet(sheetsService *sheets.Service, driveServi
 type ExportResult struct {
 	SpreadsheetID string
 	URL           string
+	Added         int // Rows written
+	Skipped       int // Articles whose link was already in the spreadsheet
 	Error         error
 }
 
-func (s *Storage) ExportToSheets(articles []models.ArticleScore, spreadsheetID string) ExportResult {
-	sheetsConfig := NewSheetsConfig(s.dataDir)
+// sheetHeader is the first row of every tab written by the export
+var sheetHeader = []interface{}{
+	"Title", "Link", "Source", "Published Date", "Score", "Exported Date",
+}
 
-	// Load credentials
-	credentials, err := os.ReadFile(sheetsConfig.CredentialsFile)
+// ExportToSheets writes articles to the spreadsheet in sheetsConfig, or to a
+// new spreadsheet in the configured folder when it has none. In append mode,
+// articles whose link is already in any tab are skipped.
+func (s *Storage) ExportToSheets(articles []models.ArticleScore, sheetsConfig *SheetsConfig) ExportResult {
+	sheetsService, driveService, err := newSheetsServices(sheetsConfig)
 	if err != nil {
-		return ExportResult{Error: fmt.Errorf("unable to read credentials file: %v", err)}
+		return ExportResult{Error: err}
 	}
 
-	// Configure the Google Sheets client with additional scopes
-	oauthConfig, err := google.JWTConfigFromJSON(credentials,
-		sheets.SpreadsheetsScope,
-		drive.DriveScope,
-		drive.DriveFileScope,
-	)
-	if err != nil {
-		return ExportResult{Error: fmt.Errorf("unable to parse credentials: %v", err)}
+	tab := "Sheet1"
+	if sheetsConfig.DatedTabs {
+		tab = time.Now().Format("2006-01-02")
 	}
 
-	// Create clients
-	client := oauthConfig.Client(context.Background())
-	sheetsService, err := sheets.New(client)
-	if err != nil {
-		return ExportResult{Error: fmt.Errorf("unable to create sheets client: %v", err)}
+	// If no spreadsheet ID provided, create a new one
+	spreadsheetID := sheetsConfig.SpreadsheetID
+	if spreadsheetID == "" {
+		spreadsheetID, err = s.newExportSpreadsheet(sheetsService, driveService, sheetsConfig, tab)
+		if err != nil {
+			return ExportResult{Error: err}
+		}
 	}
 
-	driveService, err := drive.New(client)
+	spreadsheet, err := sheetsService.Spreadsheets.Get(spreadsheetID).Fields("sheets.properties").Do()
+	if isNotFound(err) && sheetsConfig.SpreadsheetID != "" {
+		// The spreadsheet was deleted since the last export; forget it so
+		// later exports do not fail too, and start a new one
+		if err := s.forgetSpreadsheetID(spreadsheetID); err != nil {
+			return ExportResult{Error: err}
+		}
+		spreadsheetID, err = s.newExportSpreadsheet(sheetsService, driveService, sheetsConfig, tab)
+		if err != nil {
+			return ExportResult{Error: err}
+		}
+		spreadsheet, err = sheetsService.Spreadsheets.Get(spreadsheetID).Fields("sheets.properties").Do()
+	}
 	if err != nil {
-		return ExportResult{Error: fmt.Errorf("unable to create drive client: %v", err)}
+		return ExportResult{Error: fmt.Errorf("unable to open spreadsheet %s: %v", spreadsheetID, err)}
+	}
+	if len(spreadsheet.Sheets) == 0 {
+		return ExportResult{Error: fmt.Errorf("spreadsheet has no sheets")}
 	}
 
-	// If no spreadsheet ID provided, create a new one
-	if spreadsheetID == "" {
-		spreadsheetID, err = s.createNewSpreadsheet(sheetsService, driveService, sheetsConfig)
+	// Without dated tabs, rows go to the first tab whatever its name
+	sheet := spreadsheet.Sheets[0].Properties
+	if sheetsConfig.DatedTabs {
+		sheet, err = getOrAddSheet(sheetsService, spreadsheetID, spreadsheet.Sheets, tab)
 		if err != nil {
 			return ExportResult{Error: err}
 		}
-		// Save the new spreadsheet ID
-		if err := s.SaveSpreadsheetID(spreadsheetID); err != nil {
-			return ExportResult{Error: fmt.Errorf("failed to save spreadsheet ID: %v", err)}
+	}
+
+	exported := make(map[string]bool)
+	if sheetsConfig.Append {
+		exported, err = exportedLinks(sheetsService, spreadsheetID, spreadsheet.Sheets)
+		if err != nil {
+			return ExportResult{Error: err}
 		}
 	}
 
 	// Prepare data for export
-	var values [][]interface{}
-	// Add header row
-	values = append(values, []interface{}{
-		"Title", "Link", "Source", "Published Date", "Score", "Exported Date",
-	})
-
-	// Add article data
+	var rows [][]interface{}
+	skipped := 0
+	exportedDate := time.Now().Format("2006-01-02 15:04:05")
 	for _, article := range articles {
+		if link := article.Item.Link; link != "" {
+			if exported[link] {
+				skipped++
+				continue
+			}
+			exported[link] = true
+		}
+
 		// Format dates in a more readable way
 		publishedDate := article.Item.Published.Format("2006-01-02 15:04:05")
-		exportedDate := time.Now().Format("2006-01-02 15:04:05")
 
-		values = append(values, []interface{}{
+		rows = append(rows, []interface{}{
 			article.Item.Title,
 			article.Item.Link,
 			article.Item.FeedSource,
@@ -242,40 +393,50 @@ func (s *Storage) ExportToSheets(articles []models.ArticleScore, spreadsheetID s
 		})
 	}
 
-	// Create the request
-	range_ := "Sheet1!A1:F" + fmt.Sprintf("%d", len(values))
-	valueRange := &sheets.ValueRange{
-		Values: values,
+	// Generate the spreadsheet URL
+	result := ExportResult{
+		SpreadsheetID: spreadsheetID,
+		URL:           fmt.Sprintf("https://docs.google.com/spreadsheets/d/%s/edit#gid=%d", spreadsheetID, sheet.SheetId),
+		Added:         len(rows),
+		Skipped:       skipped,
 	}
-
-	// Update the spreadsheet
-	_, err = sheetsService.Spreadsheets.Values.Update(
-		spreadsheetID,
-		range_,
-		valueRange,
-	).ValueInputOption("RAW").Do()
-	if err != nil {
-		return ExportResult{Error: fmt.Errorf("unable to update spreadsheet: %v", err)}
+	if len(rows) == 0 {
+		return result
 	}
 
-	// Get the spreadsheet to find the sheet ID
-	spreadsheet, err := sheetsService.Spreadsheets.Get(spreadsheetID).Do()
+	existing, err := sheetsService.Spreadsheets.Values.Get(spreadsheetID, sheetRange(sheet.Title, "A:A")).Do()
 	if err != nil {
-		return ExportResult{Error: fmt.Errorf("unable to get spreadsheet: %v", err)}
+		return ExportResult{Error: fmt.Errorf("unable to read spreadsheet: %v", err)}
 	}
 
-	if len(spreadsheet.Sheets) == 0 {
-		return ExportResult{Error: fmt.Errorf("spreadsheet has no sheets")}
+	if len(existing.Values) > 0 {
+		_, err = sheetsService.Spreadsheets.Values.Append(
+			spreadsheetID,
+			sheetRange(sheet.Title, "A1"),
+			&sheets.ValueRange{Values: rows},
+		).ValueInputOption("RAW").InsertDataOption("INSERT_ROWS").Do()
+		if err != nil {
+			return ExportResult{Error: fmt.Errorf("unable to append to spreadsheet: %v", err)}
+		}
+		return result
 	}
 
-	sheetID := spreadsheet.Sheets[0].Properties.SheetId
+	// An empty tab gets the header row, frozen once the data is populated
+	values := append([][]interface{}{sheetHeader}, rows...)
+	_, err = sheetsService.Spreadsheets.Values.Update(
+		spreadsheetID,
+		sheetRange(sheet.Title, fmt.Sprintf("A1:F%d", len(values))),
+		&sheets.ValueRange{Values: values},
+	).ValueInputOption("RAW").Do()
+	if err != nil {
+		return ExportResult{Error: fmt.Errorf("unable to update spreadsheet: %v", err)}
+	}
 
-	// Freeze the first row after the data is populated
 	requests := []*sheets.Request{
 		{
 			UpdateSheetProperties: &sheets.UpdateSheetPropertiesRequest{
 				Properties: &sheets.SheetProperties{
-					SheetId: sheetID,
+					SheetId: sheet.SheetId,
 					GridProperties: &sheets.GridProperties{
 						FrozenRowCount: 1,
 					},
@@ -294,14 +455,161 @@ func (s *Storage) ExportToSheets(articles []models.ArticleScore, spreadsheetID s
 		return ExportResult{Error: fmt.Errorf("unable to freeze first row: %v", err)}
 	}
 
-	// Generate the spreadsheet URL
-	spreadsheetURL := fmt.Sprintf("https://docs.google.com/spreadsheets/d/%s/edit", spreadsheetID)
+	return result
+}
 
-	return ExportResult{
-		SpreadsheetID: spreadsheetID,
-		URL:           spreadsheetURL,
-		Error:         nil,
+// newExportSpreadsheet creates a spreadsheet in the RSS Reader folder of the
+// configured folder and saves its ID for appending
+func (s *Storage) newExportSpreadsheet(sheetsService *sheets.Service, driveService *drive.Service, sheetsConfig *SheetsConfig, tab string) (string, error) {
+	if err := checkFolderAccess(driveService, sheetsConfig); err != nil {
+		return "", err
+	}
+	folderID, err := s.getOrCreateRSSFolder(driveService, sheetsConfig)
+	if err != nil {
+		return "", err
+	}
+	spreadsheetID, err := s.createNewSpreadsheet(sheetsService, driveService, folderID, tab)
+	if err != nil {
+		return "", err
+	}
+	// Save the new spreadsheet ID
+	if err := s.SaveSpreadsheetID(spreadsheetID); err != nil {
+		return "", fmt.Errorf("failed to save spreadsheet ID: %v", err)
+	}
+	return spreadsheetID, nil
+}
+
+// forgetSpreadsheetID clears the saved spreadsheet ID if it is id
+func (s *Storage) forgetSpreadsheetID(id string) error {
+	saved, err := s.LoadSpreadsheetID()
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	if saved != id {
+		return nil
+	}
+	return s.SaveSpreadsheetID("")
+}
+
+// isNotFound reports whether a Google API call failed because the file
+// does not exist, or was deleted
+func isNotFound(err error) bool {
+	var apiErr *googleapi.Error
+	return errors.As(err, &apiErr) && apiErr.Code == http.StatusNotFound
+}
+
+// sheetRange returns an A1 range within the named tab, quoting the name
+func sheetRange(title, cells string) string {
+	return "'" + strings.ReplaceAll(title, "'", "''") + "'!" + cells
+}
+
+// getOrAddSheet returns the properties of the named tab, adding it when the
+// spreadsheet does not have it yet
+func getOrAddSheet(sheetsService *sheets.Service, spreadsheetID string, existing []*sheets.Sheet, title string) (*sheets.SheetProperties, error) {
+	for _, sheet := range existing {
+		if sheet.Properties.Title == title {
+			return sheet.Properties, nil
+		}
+	}
+
+	batchUpdate := &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: []*sheets.Request{
+			{AddSheet: &sheets.AddSheetRequest{Properties: &sheets.SheetProperties{Title: title}}},
+		},
+	}
+	resp, err := sheetsService.Spreadsheets.BatchUpdate(spreadsheetID, batchUpdate).Do()
+	if err != nil {
+		return nil, fmt.Errorf("unable to add sheet %q: %v", title, err)
+	}
+	if len(resp.Replies) == 0 || resp.Replies[0].AddSheet == nil {
+		return nil, fmt.Errorf("unable to add sheet %q: no reply", title)
+	}
+	return resp.Replies[0].AddSheet.Properties, nil
+}
+
+// exportedLinks returns the links in the Link column of every tab
+func exportedLinks(sheetsService *sheets.Service, spreadsheetID string, existing []*sheets.Sheet) (map[string]bool, error) {
+	ranges := make([]string, 0, len(existing))
+	for _, sheet := range existing {
+		ranges = append(ranges, sheetRange(sheet.Properties.Title, "B2:B"))
+	}
+
+	resp, err := sheetsService.Spreadsheets.Values.BatchGet(spreadsheetID).Ranges(ranges...).Do()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read exported links: %v", err)
+	}
+
+	links := make(map[string]bool)
+	for _, valueRange := range resp.ValueRanges {
+		for _, row := range valueRange.Values {
+			if len(row) > 0 {
+				if link, ok := row[0].(string); ok && link != "" {
+					links[link] = true
+				}
+			}
+		}
+	}
+	return links, nil
+}
+
+// sheetsExporter adapts ExportToSheets to the export.Exporter interface
+type sheetsExporter struct {
+	store   *Storage
+	options SheetsExportOptions
+}
+
+// SheetsExportOptions select where a Google Sheets export is written
+type SheetsExportOptions struct {
+	Folder    string // Drive folder ID or folder URL; empty is My Drive
+	Append    bool   // Add to the last exported spreadsheet instead of a new one
+	DatedTabs bool   // Write each day's exports to their own tab
+
+	OpenAuthURL func(url string) // Shows the Google sign-in page when needed
+}
+
+// SheetsExporter returns an exporter that writes to Google Sheets
+func (s *Storage) SheetsExporter(options SheetsExportOptions) export.Exporter {
+	return &sheetsExporter{store: s, options: options}
+}
+
+func (e *sheetsExporter) Name() string {
+	if e.options.Append {
+		return "Google Sheets (append to last spreadsheet)"
+	}
+	return "Google Sheets (new spreadsheet)"
+}
+
+func (e *sheetsExporter) Export(articles []models.ArticleScore) (export.Result, error) {
+	sheetsConfig, err := e.store.SheetsConfig(e.options.Folder)
+	if err != nil {
+		return export.Result{}, err
+	}
+	sheetsConfig.DatedTabs = e.options.DatedTabs
+	sheetsConfig.OpenAuthURL = e.options.OpenAuthURL
+
+	// Without a saved spreadsheet, appending starts a new one
+	if e.options.Append {
+		sheetsConfig.Append = true
+		spreadsheetID, err := e.store.LoadSpreadsheetID()
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return export.Result{}, err
+		}
+		sheetsConfig.SpreadsheetID = spreadsheetID
+	}
+
+	result := e.store.ExportToSheets(articles, sheetsConfig)
+	if result.Error != nil {
+		return export.Result{}, result.Error
 	}
+	return export.Result{
+		Location: result.URL,
+		URL:      result.URL,
+		Count:    result.Added,
+		Skipped:  result.Skipped,
+	}, nil
 }
 
 func (s *Storage) SaveSpreadsheetID(id string) error {
@@ -314,7 +622,7 @@ func (s *Storage) SaveSpreadsheetID(id string) error {
 	}
 
 	if err := os.WriteFile(path, jsonData, 0644); err != nil {
-		return fmt.Errorf("error saving spreadsheet ID: %v", err)
+		return fmt.Errorf("error saving spreadsheet ID: %w", err)
 	}
 
 	return nil
@@ -325,7 +633,7 @@ func (s *Storage) LoadSpreadsheetID() (string, error) {
 
 	data, err := os.ReadFile(path)
 	if err != nil {
-		return "", fmt.Errorf("error reading spreadsheet ID: %v", err)
+		return "", fmt.Errorf("error reading spreadsheet ID: %w", err)
 	}
 
 	var config map[string]string
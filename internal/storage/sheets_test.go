@@ -0,0 +1,508 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/sheets/v4"
+
+	"github.com/thedittmer/rss-reader/internal/models"
+)
+
+// fakeGoogle implements the parts of the Sheets and Drive APIs used by the
+// export, holding at most one spreadsheet
+type fakeGoogle struct {
+	mu       sync.Mutex
+	requests []string // "METHOD path" of every request
+
+	files       map[string]*drive.File // Drive files and folders by ID
+	nextID      int
+	spreadsheet string // ID of the spreadsheet, once created
+	tabs        []*fakeTab
+}
+
+type fakeTab struct {
+	id     int64
+	title  string
+	frozen int64
+	rows   [][]interface{}
+}
+
+func newFakeGoogle(folders ...string) *fakeGoogle {
+	f := &fakeGoogle{files: map[string]*drive.File{
+		"root": {Id: "root", MimeType: "application/vnd.google-apps.folder"},
+	}}
+	for _, id := range folders {
+		f.files[id] = &drive.File{Id: id, MimeType: "application/vnd.google-apps.folder", Parents: []string{"root"}}
+	}
+	return f
+}
+
+func (f *fakeGoogle) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests = append(f.requests, r.Method+" "+r.URL.Path)
+
+	path := r.URL.Path
+	switch {
+	case strings.HasPrefix(path, "/drive/v3/files"):
+		f.serveDrive(w, r, strings.TrimPrefix(strings.TrimPrefix(path, "/drive/v3/files"), "/"))
+	case strings.HasPrefix(path, "/v4/spreadsheets"):
+		f.serveSheets(w, r, strings.TrimPrefix(strings.TrimPrefix(path, "/v4/spreadsheets"), "/"))
+	default:
+		writeError(w, http.StatusNotFound, "unknown path "+path)
+	}
+}
+
+func (f *fakeGoogle) serveDrive(w http.ResponseWriter, r *http.Request, id string) {
+	switch {
+	case id == "" && r.Method == http.MethodGet:
+		// Only the "RSS Reader" folder query is supported
+		var found []*drive.File
+		for _, file := range f.files {
+			if file.Name == "RSS Reader" && len(file.Parents) > 0 &&
+				strings.Contains(r.URL.Query().Get("q"), "'"+file.Parents[0]+"' in parents") {
+				found = append(found, file)
+			}
+		}
+		writeJSON(w, &drive.FileList{Files: found})
+	case id == "" && r.Method == http.MethodPost:
+		var file drive.File
+		json.NewDecoder(r.Body).Decode(&file)
+		f.nextID++
+		file.Id = fmt.Sprintf("folder-%d", f.nextID)
+		f.files[file.Id] = &file
+		writeJSON(w, &file)
+	case r.Method == http.MethodGet:
+		file, ok := f.files[id]
+		if !ok {
+			writeError(w, http.StatusNotFound, "File not found: "+id)
+			return
+		}
+		writeJSON(w, file)
+	case r.Method == http.MethodPatch:
+		file, ok := f.files[id]
+		if !ok {
+			writeError(w, http.StatusNotFound, "File not found: "+id)
+			return
+		}
+		query := r.URL.Query()
+		var parents []string
+		for _, parent := range file.Parents {
+			if !strings.Contains(","+query.Get("removeParents")+",", ","+parent+",") {
+				parents = append(parents, parent)
+			}
+		}
+		if add := query.Get("addParents"); add != "" {
+			parents = append(parents, add)
+		}
+		file.Parents = parents
+		writeJSON(w, file)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, r.Method+" "+r.URL.Path)
+	}
+}
+
+func (f *fakeGoogle) serveSheets(w http.ResponseWriter, r *http.Request, path string) {
+	if path == "" && r.Method == http.MethodPost {
+		var spreadsheet sheets.Spreadsheet
+		json.NewDecoder(r.Body).Decode(&spreadsheet)
+		f.spreadsheet = "sheet-1"
+		f.files[f.spreadsheet] = &drive.File{Id: f.spreadsheet, Parents: []string{"root"}}
+		for _, sheet := range spreadsheet.Sheets {
+			f.addTab(sheet.Properties.Title)
+		}
+		writeJSON(w, f.spreadsheetResource())
+		return
+	}
+
+	id, rest, _ := strings.Cut(path, "/")
+	id, action, _ := strings.Cut(id, ":")
+	if id != f.spreadsheet {
+		writeError(w, http.StatusNotFound, "Requested entity was not found.")
+		return
+	}
+
+	switch {
+	case rest == "" && action == "" && r.Method == http.MethodGet:
+		writeJSON(w, f.spreadsheetResource())
+	case action == "batchUpdate":
+		var req sheets.BatchUpdateSpreadsheetRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		resp := &sheets.BatchUpdateSpreadsheetResponse{SpreadsheetId: id}
+		for _, request := range req.Requests {
+			reply := &sheets.Response{}
+			switch {
+			case request.AddSheet != nil:
+				tab := f.addTab(request.AddSheet.Properties.Title)
+				reply.AddSheet = &sheets.AddSheetResponse{Properties: tab.properties()}
+			case request.UpdateSheetProperties != nil:
+				props := request.UpdateSheetProperties.Properties
+				for _, tab := range f.tabs {
+					if tab.id == props.SheetId {
+						tab.frozen = props.GridProperties.FrozenRowCount
+					}
+				}
+			}
+			resp.Replies = append(resp.Replies, reply)
+		}
+		writeJSON(w, resp)
+	case rest == "values:batchGet":
+		resp := &sheets.BatchGetValuesResponse{SpreadsheetId: id}
+		for _, a1 := range r.URL.Query()["ranges"] {
+			tab, ok := f.tab(a1)
+			if !ok {
+				writeError(w, http.StatusBadRequest, "Unable to parse range: "+a1)
+				return
+			}
+			// Only the Link column below the header is requested
+			var values [][]interface{}
+			for i, row := range tab.rows {
+				if i > 0 && len(row) > 1 {
+					values = append(values, []interface{}{row[1]})
+				}
+			}
+			resp.ValueRanges = append(resp.ValueRanges, &sheets.ValueRange{Range: a1, Values: values})
+		}
+		writeJSON(w, resp)
+	case strings.HasPrefix(rest, "values/"):
+		a1, valuesAction, _ := strings.Cut(strings.TrimPrefix(rest, "values/"), ":")
+		tab, ok := f.tab(a1)
+		if !ok {
+			writeError(w, http.StatusBadRequest, "Unable to parse range: "+a1)
+			return
+		}
+		switch {
+		case r.Method == http.MethodGet:
+			writeJSON(w, &sheets.ValueRange{Range: a1, Values: tab.rows})
+		case r.Method == http.MethodPut:
+			var values sheets.ValueRange
+			json.NewDecoder(r.Body).Decode(&values)
+			for i, row := range values.Values {
+				if i < len(tab.rows) {
+					tab.rows[i] = row
+				} else {
+					tab.rows = append(tab.rows, row)
+				}
+			}
+			writeJSON(w, &sheets.UpdateValuesResponse{UpdatedRows: int64(len(values.Values))})
+		case valuesAction == "append":
+			var values sheets.ValueRange
+			json.NewDecoder(r.Body).Decode(&values)
+			tab.rows = append(tab.rows, values.Values...)
+			writeJSON(w, &sheets.AppendValuesResponse{SpreadsheetId: id})
+		default:
+			writeError(w, http.StatusMethodNotAllowed, r.Method+" "+r.URL.Path)
+		}
+	default:
+		writeError(w, http.StatusMethodNotAllowed, r.Method+" "+r.URL.Path)
+	}
+}
+
+func (f *fakeGoogle) addTab(title string) *fakeTab {
+	tab := &fakeTab{id: int64(len(f.tabs) * 100), title: title}
+	f.tabs = append(f.tabs, tab)
+	return tab
+}
+
+// tab returns the tab named in an A1 range such as 'Sheet1'!A1:F3
+func (f *fakeGoogle) tab(a1 string) (*fakeTab, bool) {
+	name, _, ok := strings.Cut(a1, "!")
+	if !ok {
+		return nil, false
+	}
+	name = strings.ReplaceAll(strings.Trim(name, "'"), "''", "'")
+	for _, tab := range f.tabs {
+		if tab.title == name {
+			return tab, true
+		}
+	}
+	return nil, false
+}
+
+func (f *fakeGoogle) spreadsheetResource() *sheets.Spreadsheet {
+	spreadsheet := &sheets.Spreadsheet{SpreadsheetId: f.spreadsheet}
+	for _, tab := range f.tabs {
+		spreadsheet.Sheets = append(spreadsheet.Sheets, &sheets.Sheet{Properties: tab.properties()})
+	}
+	return spreadsheet
+}
+
+func (t *fakeTab) properties() *sheets.SheetProperties {
+	return &sheets.SheetProperties{
+		SheetId:         t.id,
+		Title:           t.title,
+		GridProperties:  &sheets.GridProperties{FrozenRowCount: t.frozen},
+		ForceSendFields: []string{"SheetId"},
+	}
+}
+
+func (f *fakeGoogle) count(request string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	n := 0
+	for _, r := range f.requests {
+		if r == request {
+			n++
+		}
+	}
+	return n
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, code int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	fmt.Fprintf(w, `{"error":{"code":%d,"message":%q}}`, code, message)
+}
+
+// newTestExport returns a storage in a temporary directory and Sheets
+// settings pointing at a fake server
+func newTestExport(t *testing.T, fake *fakeGoogle, folderID string) (*Storage, *SheetsConfig) {
+	t.Helper()
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	store := &Storage{dataDir: t.TempDir()}
+	sheetsConfig := NewSheetsConfig(store.dataDir)
+	sheetsConfig.FolderID = folderID
+	sheetsConfig.HTTPClient = server.Client()
+	sheetsConfig.SheetsEndpoint = server.URL + "/"
+	sheetsConfig.DriveEndpoint = server.URL + "/drive/v3/"
+	return store, sheetsConfig
+}
+
+func testArticles(links ...string) []models.ArticleScore {
+	var articles []models.ArticleScore
+	for i, link := range links {
+		articles = append(articles, models.ArticleScore{
+			Item: models.FeedItem{
+				Title:      fmt.Sprintf("Article %d", i+1),
+				Link:       link,
+				FeedSource: "Test Feed",
+				Published:  time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC),
+			},
+			Score: 1.5,
+		})
+	}
+	return articles
+}
+
+func TestExportToSheetsCreatesSpreadsheetInFolder(t *testing.T) {
+	fake := newFakeGoogle("shared")
+	store, sheetsConfig := newTestExport(t, fake, "shared")
+
+	result := store.ExportToSheets(testArticles("https://a.example/1", "https://a.example/2"), sheetsConfig)
+	if result.Error != nil {
+		t.Fatalf("ExportToSheets: %v", result.Error)
+	}
+
+	if result.SpreadsheetID != "sheet-1" || result.Added != 2 || result.Skipped != 0 {
+		t.Errorf("result = %+v, want sheet-1 with 2 added", result)
+	}
+	if !strings.HasPrefix(result.URL, "https://docs.google.com/spreadsheets/d/sheet-1/edit") {
+		t.Errorf("URL = %q", result.URL)
+	}
+
+	// The spreadsheet is moved out of My Drive into RSS Reader in the folder
+	var rssFolder *drive.File
+	for _, file := range fake.files {
+		if file.Name == "RSS Reader" {
+			rssFolder = file
+		}
+	}
+	if rssFolder == nil || len(rssFolder.Parents) != 1 || rssFolder.Parents[0] != "shared" {
+		t.Fatalf("RSS Reader folder = %+v, want it inside the shared folder", rssFolder)
+	}
+	if parents := fake.files["sheet-1"].Parents; len(parents) != 1 || parents[0] != rssFolder.Id {
+		t.Errorf("spreadsheet parents = %v, want [%s]", parents, rssFolder.Id)
+	}
+
+	if len(fake.tabs) != 1 || fake.tabs[0].title != "Sheet1" {
+		t.Fatalf("tabs = %+v, want Sheet1 only", fake.tabs)
+	}
+	tab := fake.tabs[0]
+	if len(tab.rows) != 3 || tab.rows[0][0] != "Title" || tab.rows[2][1] != "https://a.example/2" {
+		t.Errorf("rows = %v, want header and two articles", tab.rows)
+	}
+	if tab.rows[1][4] != "1.50" || tab.rows[1][3] != "2024-03-01 12:00:00" {
+		t.Errorf("row = %v, want formatted score and date", tab.rows[1])
+	}
+	if tab.frozen != 1 {
+		t.Errorf("frozen rows = %d, want 1", tab.frozen)
+	}
+
+	if id, err := store.LoadSpreadsheetID(); err != nil || id != "sheet-1" {
+		t.Errorf("saved spreadsheet ID = %q, %v", id, err)
+	}
+}
+
+func TestExportToSheetsReusesRSSReaderFolder(t *testing.T) {
+	fake := newFakeGoogle()
+	fake.files["existing"] = &drive.File{Id: "existing", Name: "RSS Reader", Parents: []string{"root"}}
+	store, sheetsConfig := newTestExport(t, fake, RootFolder)
+
+	if result := store.ExportToSheets(testArticles("https://a.example/1"), sheetsConfig); result.Error != nil {
+		t.Fatalf("ExportToSheets: %v", result.Error)
+	}
+
+	if n := fake.count("POST /drive/v3/files"); n != 0 {
+		t.Errorf("created %d folders, want the existing one reused", n)
+	}
+	if parents := fake.files["sheet-1"].Parents; len(parents) != 1 || parents[0] != "existing" {
+		t.Errorf("spreadsheet parents = %v, want [existing]", parents)
+	}
+}
+
+func TestExportToSheetsInaccessibleFolder(t *testing.T) {
+	fake := newFakeGoogle()
+	store, sheetsConfig := newTestExport(t, fake, "missing")
+
+	result := store.ExportToSheets(testArticles("https://a.example/1"), sheetsConfig)
+	if result.Error == nil || !strings.Contains(result.Error.Error(), "drive.google.com/drive/folders/missing") {
+		t.Fatalf("error = %v, want setup instructions for the folder", result.Error)
+	}
+	if fake.spreadsheet != "" {
+		t.Error("spreadsheet created although the folder is not accessible")
+	}
+}
+
+func TestExportToSheetsAppendSkipsExportedLinks(t *testing.T) {
+	fake := newFakeGoogle()
+	store, sheetsConfig := newTestExport(t, fake, RootFolder)
+
+	first := store.ExportToSheets(testArticles("https://a.example/1", "https://a.example/2"), sheetsConfig)
+	if first.Error != nil {
+		t.Fatalf("first export: %v", first.Error)
+	}
+
+	sheetsConfig.Append = true
+	sheetsConfig.SpreadsheetID = first.SpreadsheetID
+	second := store.ExportToSheets(testArticles("https://a.example/2", "https://a.example/3", "https://a.example/3"), sheetsConfig)
+	if second.Error != nil {
+		t.Fatalf("second export: %v", second.Error)
+	}
+
+	if second.Added != 1 || second.Skipped != 2 {
+		t.Errorf("added %d, skipped %d; want 1 added, 2 skipped", second.Added, second.Skipped)
+	}
+	if n := fake.count("POST /v4/spreadsheets"); n != 1 {
+		t.Errorf("created %d spreadsheets, want 1", n)
+	}
+	rows := fake.tabs[0].rows
+	if len(rows) != 4 || rows[3][1] != "https://a.example/3" {
+		t.Errorf("rows = %v, want the new link appended after the header and two rows", rows)
+	}
+}
+
+func TestExportToSheetsDatedTabs(t *testing.T) {
+	fake := newFakeGoogle()
+	store, sheetsConfig := newTestExport(t, fake, RootFolder)
+
+	first := store.ExportToSheets(testArticles("https://a.example/1"), sheetsConfig)
+	if first.Error != nil {
+		t.Fatalf("first export: %v", first.Error)
+	}
+
+	sheetsConfig.Append = true
+	sheetsConfig.DatedTabs = true
+	sheetsConfig.SpreadsheetID = first.SpreadsheetID
+	second := store.ExportToSheets(testArticles("https://a.example/1", "https://a.example/2"), sheetsConfig)
+	if second.Error != nil {
+		t.Fatalf("second export: %v", second.Error)
+	}
+
+	today := time.Now().Format("2006-01-02")
+	if len(fake.tabs) != 2 || fake.tabs[1].title != today {
+		t.Fatalf("tabs = %+v, want Sheet1 and %s", fake.tabs, today)
+	}
+	tab := fake.tabs[1]
+	if len(tab.rows) != 2 || tab.rows[0][0] != "Title" || tab.rows[1][1] != "https://a.example/2" {
+		t.Errorf("rows = %v, want header and the one new link", tab.rows)
+	}
+	if tab.frozen != 1 {
+		t.Errorf("frozen rows = %d, want 1", tab.frozen)
+	}
+	if !strings.HasSuffix(second.URL, fmt.Sprintf("#gid=%d", tab.id)) {
+		t.Errorf("URL = %q, want it to open the new tab", second.URL)
+	}
+}
+
+func TestExportToSheetsMissingSpreadsheet(t *testing.T) {
+	fake := newFakeGoogle()
+	store, sheetsConfig := newTestExport(t, fake, RootFolder)
+	if err := store.SaveSpreadsheetID("deleted"); err != nil {
+		t.Fatal(err)
+	}
+	sheetsConfig.Append = true
+	sheetsConfig.SpreadsheetID = "deleted"
+
+	// A deleted spreadsheet is replaced by a new one
+	result := store.ExportToSheets(testArticles("https://a.example/1"), sheetsConfig)
+	if result.Error != nil {
+		t.Fatalf("ExportToSheets: %v", result.Error)
+	}
+	if result.SpreadsheetID != "sheet-1" || result.Added != 1 {
+		t.Errorf("result = %+v, want a new spreadsheet with 1 added", result)
+	}
+
+	saved, err := store.LoadSpreadsheetID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved != "sheet-1" {
+		t.Errorf("saved spreadsheet ID = %q, want the new spreadsheet", saved)
+	}
+}
+
+func TestExportToSheetsMissingSpreadsheetForgetsID(t *testing.T) {
+	fake := newFakeGoogle()
+	store, sheetsConfig := newTestExport(t, fake, "missing")
+	if err := store.SaveSpreadsheetID("deleted"); err != nil {
+		t.Fatal(err)
+	}
+	sheetsConfig.Append = true
+	sheetsConfig.SpreadsheetID = "deleted"
+
+	// Even when no new spreadsheet can be created, the deleted one is not
+	// tried again
+	result := store.ExportToSheets(testArticles("https://a.example/1"), sheetsConfig)
+	if result.Error == nil {
+		t.Fatal("export succeeded although the folder is not accessible")
+	}
+	if saved, _ := store.LoadSpreadsheetID(); saved != "" {
+		t.Errorf("saved spreadsheet ID = %q, want it cleared", saved)
+	}
+}
+
+func TestParseFolderID(t *testing.T) {
+	tests := []struct {
+		in, want string
+		wantErr  bool
+	}{
+		{"", RootFolder, false},
+		{"root", RootFolder, false},
+		{"17sE3dh1ujQtuecSLdutpTGsinpudD3Qb", "17sE3dh1ujQtuecSLdutpTGsinpudD3Qb", false},
+		{"https://drive.google.com/drive/folders/abc_DEF-1", "abc_DEF-1", false},
+		{"https://drive.google.com/drive/u/0/folders/abc?usp=sharing", "abc", false},
+		{"https://drive.google.com/file/d/abc/view", "", true},
+		{"not a folder", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseFolderID(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseFolderID(%q) = %q, %v; want %q, error %v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Action is a command that can be bound to a key
+type Action string
+
+const (
+	ActionNextPage    Action = "nextPage"
+	ActionPrevPage    Action = "prevPage"
+	ActionOpenArticle Action = "openArticle"
+	ActionView        Action = "view"
+	ActionBack        Action = "back"
+	ActionHelp        Action = "help"
+	ActionToggleRead  Action = "toggleRead"
+	ActionMarkAllRead Action = "markAllRead"
+	ActionUnreadOnly  Action = "unreadOnly"
+	ActionSort        Action = "sort"
+	ActionExport      Action = "export"
+	ActionInteresting Action = "interesting"
+	ActionSkip        Action = "skip"
+)
+
+// Screen groups the actions available on one screen. Keys only need to be
+// unique within a screen.
+type Screen int
+
+const (
+	ScreenList Screen = iota
+	ScreenRecommendations
+	ScreenArticle
+)
+
+// Binding is an action with the key currently bound to it
+type Binding struct {
+	Action      Action
+	Key         string
+	Description string
+}
+
+type actionInfo struct {
+	key         string // Default key
+	alias       string // Word accepted where commands are typed
+	description string
+}
+
+var actions = map[Action]actionInfo{
+	ActionNextPage:    {"n", "next", "Next page"},
+	ActionPrevPage:    {"p", "prev", "Previous page"},
+	ActionOpenArticle: {"o", "open", "Open in browser"},
+	ActionView:        {"v", "view", "View article details"},
+	ActionBack:        {"b", "back", "Go back"},
+	ActionHelp:        {"h", "help", "Show help"},
+	ActionToggleRead:  {"m", "mark", "Toggle read/unread"},
+	ActionMarkAllRead: {"a", "all", "Mark all as read"},
+	ActionUnreadOnly:  {"u", "unread", "Toggle unread only"},
+	ActionSort:        {"s", "sort", "Toggle sort (relevance/date)"},
+	ActionExport:      {"e", "export", "Export articles"},
+	ActionInteresting: {"y", "yes", "Mark as interesting and continue"},
+	ActionSkip:        {"n", "no", "Skip to next article"},
+}
+
+var screenActions = map[Screen][]Action{
+	ScreenList: {
+		ActionView, ActionOpenArticle, ActionNextPage, ActionPrevPage,
+		ActionToggleRead, ActionMarkAllRead, ActionUnreadOnly,
+		ActionBack, ActionHelp,
+	},
+	ScreenRecommendations: {
+		ActionView, ActionOpenArticle, ActionNextPage, ActionPrevPage,
+		ActionSort, ActionToggleRead, ActionMarkAllRead, ActionExport,
+		ActionBack, ActionHelp,
+	},
+	ScreenArticle: {
+		ActionInteresting, ActionSkip, ActionOpenArticle, ActionToggleRead,
+		ActionBack, ActionHelp,
+	},
+}
+
+// Keys the terminal reader already uses for arrows and Enter
+var reservedKeys = map[string]string{
+	"A": "up arrow", "B": "down arrow", "C": "right arrow", "D": "left arrow",
+	"\r": "enter",
+}
+
+// KeyMap resolves keys to actions for every list and article screen
+type KeyMap struct {
+	keys map[Action]string
+}
+
+func DefaultKeyMap() *KeyMap {
+	keys := make(map[Action]string, len(actions))
+	for action, info := range actions {
+		keys[action] = info.key
+	}
+	return &KeyMap{keys: keys}
+}
+
+// NewKeyMap applies overrides, keyed by action name, to the default bindings
+// and reports unknown actions, invalid keys and keys bound twice on a screen
+func NewKeyMap(overrides map[Action]string) (*KeyMap, error) {
+	km := DefaultKeyMap()
+
+	for action, key := range overrides {
+		if _, ok := actions[action]; !ok {
+			return nil, fmt.Errorf("unknown key binding action %q (valid actions: %s)", action, strings.Join(actionNames(), ", "))
+		}
+		if key == "" {
+			continue
+		}
+		if len(key) != 1 || key[0] < '!' || key[0] > '~' {
+			return nil, fmt.Errorf("key for %s must be a single printable character, got %q", action, key)
+		}
+		if name, ok := reservedKeys[key]; ok {
+			return nil, fmt.Errorf("key %q for %s is reserved for %s", key, action, name)
+		}
+		km.keys[action] = key
+	}
+
+	if err := km.checkConflicts(); err != nil {
+		return nil, err
+	}
+	return km, nil
+}
+
+func (k *KeyMap) checkConflicts() error {
+	screens := []Screen{ScreenList, ScreenRecommendations, ScreenArticle}
+	for _, screen := range screens {
+		bound := make(map[string]Action)
+		for _, action := range screenActions[screen] {
+			key := k.keys[action]
+			if other, ok := bound[key]; ok {
+				return fmt.Errorf("key %q is bound to both %s and %s", key, other, action)
+			}
+			bound[key] = action
+		}
+	}
+	return nil
+}
+
+// Key returns the key bound to an action
+func (k *KeyMap) Key(action Action) string {
+	return k.keys[action]
+}
+
+// Lookup returns the action for a key press or typed command on a screen.
+// Typed commands may also use the action's word, such as "back".
+func (k *KeyMap) Lookup(screen Screen, input string) (Action, bool) {
+	for _, action := range screenActions[screen] {
+		if input == k.keys[action] {
+			return action, true
+		}
+	}
+	input = strings.ToLower(strings.TrimSpace(input))
+	for _, action := range screenActions[screen] {
+		if input != "" && input == actions[action].alias {
+			return action, true
+		}
+	}
+	return "", false
+}
+
+// Bindings lists the active bindings of a screen in display order
+func (k *KeyMap) Bindings(screen Screen) []Binding {
+	var bindings []Binding
+	for _, action := range screenActions[screen] {
+		bindings = append(bindings, Binding{
+			Action:      action,
+			Key:         k.keys[action],
+			Description: actions[action].description,
+		})
+	}
+	return bindings
+}
+
+func actionNames() []string {
+	names := make([]string, 0, len(actions))
+	for action := range actions {
+		names = append(names, string(action))
+	}
+	sort.Strings(names)
+	return names
+}
@@ -1,121 +1,176 @@
 package ui
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"strings"
 
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Styles used across the UI, rebuilt by ApplyTheme
 var (
-	// Core color palette inspired by pnpm with additional vibrant colors
-	primaryColor   = lipgloss.Color("#0969DA") // GitHub blue
-	secondaryColor = lipgloss.Color("#8250DF") // Purple
-	accentColor    = lipgloss.Color("#2DA44E") // Green
-	warningColor   = lipgloss.Color("#D29922") // Orange
-	errorColor     = lipgloss.Color("#CF222E") // Red
-	textColor      = lipgloss.Color("#FFFFFF") // White
-	dimColor       = lipgloss.Color("#6E7681") // Gray
-	linkColor      = lipgloss.Color("#58A6FF") // Light blue
-	scoreColor     = lipgloss.Color("#F778BA") // Pink
-	titleColor     = lipgloss.Color("#39D353") // Bright green
-	dateColor      = lipgloss.Color("#A371F7") // Light purple
-	sourceColor    = lipgloss.Color("#FFA657") // Light orange
-	bgColor        = lipgloss.Color("#1F2328") // Dark background
-	selectedBg     = lipgloss.Color("#2D333B") // Selected item background
+	HeaderStyle     lipgloss.Style
+	CommandStyle    lipgloss.Style
+	ArrowStyle      lipgloss.Style
+	SuccessStyle    lipgloss.Style
+	ErrorStyle      lipgloss.Style
+	TextStyle       lipgloss.Style
+	DimStyle        lipgloss.Style
+	LinkStyle       lipgloss.Style
+	ScoreStyle      lipgloss.Style
+	TitleStyle      lipgloss.Style
+	DateStyle       lipgloss.Style
+	SourceStyle     lipgloss.Style
+	SectionStyle    lipgloss.Style
+	SelectedStyle   lipgloss.Style
+	UnselectedStyle lipgloss.Style
+	KeyStyle        lipgloss.Style
+	MenuItemStyle   lipgloss.Style
+	StatusStyle     lipgloss.Style
+	HighlightStyle  lipgloss.Style
+	UnreadStyle     lipgloss.Style
+	ReadStyle       lipgloss.Style
+	BoxStyle        lipgloss.Style
+)
+
+func init() {
+	buildStyles(DarkTheme)
+}
 
+// buildStyles derives every style from the theme's palette
+func buildStyles(t Theme) {
 	// Enhanced styles with consistent color usage
 	HeaderStyle = lipgloss.NewStyle().
-			Foreground(primaryColor).
-			Bold(true).
-			Padding(1, 0).
-			BorderStyle(lipgloss.RoundedBorder()).
-			BorderForeground(accentColor)
+		Foreground(t.Primary).
+		Bold(true).
+		Padding(1, 0).
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(t.Accent)
 
 	CommandStyle = lipgloss.NewStyle().
-			Foreground(secondaryColor).
-			Bold(true)
+		Foreground(t.Secondary).
+		Bold(true)
 
 	ArrowStyle = lipgloss.NewStyle().
-			Foreground(accentColor).
-			SetString("│ ")
+		Foreground(t.Accent).
+		SetString("│ ")
 
 	SuccessStyle = lipgloss.NewStyle().
-			Foreground(accentColor).
-			Bold(true)
+		Foreground(t.Accent).
+		Bold(true)
 
 	ErrorStyle = lipgloss.NewStyle().
-			Foreground(errorColor).
-			Bold(true)
+		Foreground(t.Error).
+		Bold(true)
 
 	TextStyle = lipgloss.NewStyle().
-			Foreground(textColor)
+		Foreground(t.Text)
 
 	DimStyle = lipgloss.NewStyle().
-			Foreground(dimColor)
+		Foreground(t.Dim)
 
 	LinkStyle = lipgloss.NewStyle().
-			Foreground(linkColor).
-			Underline(true)
+		Foreground(t.Link).
+		Underline(true)
 
 	ScoreStyle = lipgloss.NewStyle().
-			Foreground(scoreColor).
-			Bold(true)
+		Foreground(t.Score).
+		Bold(true)
 
 	TitleStyle = lipgloss.NewStyle().
-			Foreground(titleColor).
-			Bold(true).
-			BorderStyle(lipgloss.RoundedBorder()).
-			BorderForeground(accentColor).
-			Padding(0, 1)
+		Foreground(t.Title).
+		Bold(true).
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(t.Accent).
+		Padding(0, 1)
 
 	DateStyle = lipgloss.NewStyle().
-			Foreground(dateColor).
-			Italic(true)
+		Foreground(t.Date).
+		Italic(true)
 
 	SourceStyle = lipgloss.NewStyle().
-			Foreground(sourceColor).
-			Bold(true)
+		Foreground(t.Source).
+		Bold(true)
 
 	SectionStyle = lipgloss.NewStyle().
-			Foreground(secondaryColor).
-			Bold(true).
-			Padding(0, 0, 1, 0).
-			BorderStyle(lipgloss.RoundedBorder()).
-			BorderForeground(accentColor)
+		Foreground(t.Secondary).
+		Bold(true).
+		Padding(0, 0, 1, 0).
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(t.Accent)
 
 	SelectedStyle = lipgloss.NewStyle().
-			Foreground(primaryColor).
-			Background(selectedBg).
-			Bold(true).
-			SetString("▶")
+		Foreground(t.Primary).
+		Background(t.Selected).
+		Bold(true).
+		SetString("▶")
 
 	UnselectedStyle = lipgloss.NewStyle().
-			Foreground(dimColor).
-			SetString(" ")
+		Foreground(t.Dim).
+		SetString(" ")
 
 	KeyStyle = lipgloss.NewStyle().
-			Foreground(secondaryColor).
-			Bold(true).
-			Padding(0, 1).
-			BorderStyle(lipgloss.RoundedBorder()).
-			BorderForeground(accentColor)
+		Foreground(t.Secondary).
+		Bold(true).
+		Padding(0, 1).
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(t.Accent)
 
 	// New styles for enhanced UI elements
 	MenuItemStyle = lipgloss.NewStyle().
-			Foreground(textColor).
-			Background(bgColor).
-			Padding(0, 1).
-			MarginLeft(2)
+		Foreground(t.Text).
+		Background(t.Background).
+		Padding(0, 1).
+		MarginLeft(2)
 
 	StatusStyle = lipgloss.NewStyle().
-			Foreground(accentColor).
-			Background(selectedBg).
-			Padding(0, 1).
-			Bold(true)
+		Foreground(t.Accent).
+		Background(t.Selected).
+		Padding(0, 1).
+		Bold(true)
 
 	HighlightStyle = lipgloss.NewStyle().
-			Foreground(scoreColor).
-			Bold(true).
-			Underline(true)
+		Foreground(t.Score).
+		Bold(true).
+		Underline(true)
+
+	UnreadStyle = lipgloss.NewStyle().
+		Foreground(t.Accent).
+		Bold(true).
+		SetString("●")
+
+	ReadStyle = lipgloss.NewStyle().
+		Foreground(t.Dim).
+		SetString(" ")
 
 	BoxStyle = lipgloss.NewStyle().
-			BorderStyle(lipgloss.RoundedBorder()).
-			BorderForeground(accentColor).
-			Padding(0, 1)
-)
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(t.Accent).
+		Padding(0, 1)
+}
+
+// Highlight renders text in base, with the byte ranges in spans rendered in
+// HighlightStyle. Spans must be in order and not overlap.
+func Highlight(text string, spans [][2]int, base lipgloss.Style) string {
+	if len(spans) == 0 {
+		return base.Render(text)
+	}
+
+	// Segments are rendered without the padding and border, which base
+	// adds around the whole text instead
+	inner := base.UnsetPadding().UnsetMargins().UnsetBorderStyle()
+	highlight := HighlightStyle.Inherit(inner)
+
+	var sb strings.Builder
+	last := 0
+	for _, span := range spans {
+		if span[0] > last {
+			sb.WriteString(inner.Render(text[last:span[0]]))
+		}
+		sb.WriteString(highlight.Render(text[span[0]:span[1]]))
+		last = span[1]
+	}
+	if last < len(text) {
+		sb.WriteString(inner.Render(text[last:]))
+	}
+	return base.Render(sb.String())
+}
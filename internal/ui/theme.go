@@ -0,0 +1,152 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// Theme is the color palette every style is built from
+type Theme struct {
+	Name       string
+	Primary    lipgloss.TerminalColor
+	Secondary  lipgloss.TerminalColor
+	Accent     lipgloss.TerminalColor
+	Warning    lipgloss.TerminalColor
+	Error      lipgloss.TerminalColor
+	Text       lipgloss.TerminalColor
+	Dim        lipgloss.TerminalColor
+	Link       lipgloss.TerminalColor
+	Score      lipgloss.TerminalColor
+	Title      lipgloss.TerminalColor
+	Date       lipgloss.TerminalColor
+	Source     lipgloss.TerminalColor
+	Background lipgloss.TerminalColor
+	Selected   lipgloss.TerminalColor // Background of the selected item
+}
+
+var (
+	// Core color palette inspired by pnpm with additional vibrant colors
+	DarkTheme = Theme{
+		Name:       "dark",
+		Primary:    lipgloss.Color("#0969DA"), // GitHub blue
+		Secondary:  lipgloss.Color("#8250DF"), // Purple
+		Accent:     lipgloss.Color("#2DA44E"), // Green
+		Warning:    lipgloss.Color("#D29922"), // Orange
+		Error:      lipgloss.Color("#CF222E"), // Red
+		Text:       lipgloss.Color("#FFFFFF"), // White
+		Dim:        lipgloss.Color("#6E7681"), // Gray
+		Link:       lipgloss.Color("#58A6FF"), // Light blue
+		Score:      lipgloss.Color("#F778BA"), // Pink
+		Title:      lipgloss.Color("#39D353"), // Bright green
+		Date:       lipgloss.Color("#A371F7"), // Light purple
+		Source:     lipgloss.Color("#FFA657"), // Light orange
+		Background: lipgloss.Color("#1F2328"), // Dark background
+		Selected:   lipgloss.Color("#2D333B"),
+	}
+
+	// Darker shades of the same palette for light terminal backgrounds
+	LightTheme = Theme{
+		Name:       "light",
+		Primary:    lipgloss.Color("#0969DA"),
+		Secondary:  lipgloss.Color("#8250DF"),
+		Accent:     lipgloss.Color("#1A7F37"),
+		Warning:    lipgloss.Color("#9A6700"),
+		Error:      lipgloss.Color("#CF222E"),
+		Text:       lipgloss.Color("#1F2328"),
+		Dim:        lipgloss.Color("#656D76"),
+		Link:       lipgloss.Color("#0550AE"),
+		Score:      lipgloss.Color("#BF3989"),
+		Title:      lipgloss.Color("#116329"),
+		Date:       lipgloss.Color("#6639BA"),
+		Source:     lipgloss.Color("#BC4C00"),
+		Background: lipgloss.Color("#FFFFFF"),
+		Selected:   lipgloss.Color("#DDF4FF"),
+	}
+
+	// Bright colors on black for maximum legibility
+	HighContrastTheme = Theme{
+		Name:       "high-contrast",
+		Primary:    lipgloss.Color("#71B7FF"),
+		Secondary:  lipgloss.Color("#DBB7FF"),
+		Accent:     lipgloss.Color("#26CD4D"),
+		Warning:    lipgloss.Color("#F0B72F"),
+		Error:      lipgloss.Color("#FF9492"),
+		Text:       lipgloss.Color("#FFFFFF"),
+		Dim:        lipgloss.Color("#D9DEE3"),
+		Link:       lipgloss.Color("#74B9FF"),
+		Score:      lipgloss.Color("#FF8DC7"),
+		Title:      lipgloss.Color("#4AE168"),
+		Date:       lipgloss.Color("#DBB7FF"),
+		Source:     lipgloss.Color("#FFB757"),
+		Background: lipgloss.Color("#000000"),
+		Selected:   lipgloss.Color("#525964"),
+	}
+
+	// No colors at all; styles keep only bold, italic and underline
+	MonochromeTheme = Theme{
+		Name:       "monochrome",
+		Primary:    lipgloss.NoColor{},
+		Secondary:  lipgloss.NoColor{},
+		Accent:     lipgloss.NoColor{},
+		Warning:    lipgloss.NoColor{},
+		Error:      lipgloss.NoColor{},
+		Text:       lipgloss.NoColor{},
+		Dim:        lipgloss.NoColor{},
+		Link:       lipgloss.NoColor{},
+		Score:      lipgloss.NoColor{},
+		Title:      lipgloss.NoColor{},
+		Date:       lipgloss.NoColor{},
+		Source:     lipgloss.NoColor{},
+		Background: lipgloss.NoColor{},
+		Selected:   lipgloss.NoColor{},
+	}
+)
+
+var themes = map[string]Theme{
+	DarkTheme.Name:         DarkTheme,
+	LightTheme.Name:        LightTheme,
+	HighContrastTheme.Name: HighContrastTheme,
+	MonochromeTheme.Name:   MonochromeTheme,
+}
+
+// ThemeByName returns one of the built-in themes
+func ThemeByName(name string) (Theme, error) {
+	theme, ok := themes[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return Theme{}, fmt.Errorf("unknown theme %q (valid themes: %s)", name, strings.Join(ThemeNames(), ", "))
+	}
+	return theme, nil
+}
+
+// ThemeNames lists the built-in themes in alphabetical order
+func ThemeNames() []string {
+	names := make([]string, 0, len(themes))
+	for name := range themes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// WithAccent returns a copy of the theme using color as its accent.
+// Monochrome themes stay colorless.
+func (t Theme) WithAccent(color string) Theme {
+	if color == "" || t.Name == MonochromeTheme.Name {
+		return t
+	}
+	t.Accent = lipgloss.Color(color)
+	return t
+}
+
+// ApplyTheme rebuilds every style from the theme. Terminals that cannot show
+// colors, or where NO_COLOR is set, always get the monochrome theme.
+func ApplyTheme(t Theme) {
+	if lipgloss.ColorProfile() == termenv.Ascii {
+		t = MonochromeTheme
+	}
+	buildStyles(t)
+}
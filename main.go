@@ -1,9 +1,9 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
-	"math/rand"
 	"os"
 	"os/exec"
 	"os/signal"
@@ -21,7 +21,12 @@ import (
 	"net/url"
 
 	"github.com/mmcdole/gofeed"
+	"github.com/thedittmer/rss-reader/internal/config"
+	"github.com/thedittmer/rss-reader/internal/export"
+	"github.com/thedittmer/rss-reader/internal/fetcher"
 	"github.com/thedittmer/rss-reader/internal/models"
+	"github.com/thedittmer/rss-reader/internal/opml"
+	"github.com/thedittmer/rss-reader/internal/search"
 	"github.com/thedittmer/rss-reader/internal/storage"
 	"github.com/thedittmer/rss-reader/internal/ui"
 	"golang.org/x/term"
@@ -36,10 +41,36 @@ const (
 
 // Types
 type App struct {
-	store   *storage.Storage
-	profile *models.UserProfile
-	feeds   []string
-	items   []models.FeedItem
+	store    *storage.Storage
+	config   *config.Config
+	profile  *models.UserProfile
+	feeds    []models.Feed
+	cached   []models.FeedItem // Every stored article, before deduplication
+	items    []models.FeedItem // Articles shown to the user, duplicates merged
+	index    *search.Index     // Full-text index over items
+	feedMeta map[string]models.FeedMeta
+	fetcher  *fetcher.Fetcher
+
+	// Outcome of the most recent refresh, keyed by feed URL
+	lastRefresh map[string]models.RefreshResult
+
+	// Cancel the foreground and background refresh in progress, if any
+	refreshMu        sync.Mutex
+	cancelRefresh    context.CancelFunc
+	cancelBackground context.CancelFunc
+
+	// fetchMu serializes refreshes; mu guards state shared with the
+	// background refresh: feeds, cached, items, index, feedMeta,
+	// lastRefresh, newArticles, refreshErr and category
+	fetchMu     sync.Mutex
+	mu          sync.Mutex
+	newArticles int   // Added by background refreshes since last viewed
+	refreshErr  error // Error of the last background refresh, if it failed
+
+	// Only articles from feeds in this category are shown, when set
+	category string
+
+	keys *ui.KeyMap
 }
 
 type keyPress struct {
@@ -49,16 +80,6 @@ type keyPress struct {
 
 // Main function and initialization
 func main() {
-	// Initialize signal handling
-	c := make(chan os.Signal, 1)
-	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
-
-	go func() {
-		<-c
-		fmt.Println("\nReceived interrupt signal. Saving and exiting...")
-		os.Exit(0)
-	}()
-
 	// Initialize storage
 	store, err := storage.NewStorage()
 	if err != nil {
@@ -67,10 +88,37 @@ func main() {
 
 	// Initialize app
 	app := NewApp(store)
+
+	// Subcommands run without the interactive interface
+	if len(os.Args) > 1 {
+		os.Exit(runCommand(app, os.Args[1:]))
+	}
+
+	// Initialize signal handling
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		for sig := range c {
+			// Ctrl-C during a refresh aborts the refresh instead of the app
+			if sig == os.Interrupt && app.cancelActiveRefresh() {
+				continue
+			}
+			fmt.Println("\nReceived interrupt signal. Saving and exiting...")
+			os.Exit(0)
+		}
+	}()
+
 	app.Run()
 }
 
 func NewApp(store *storage.Storage) *App {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Printf("Error loading config, using defaults: %v", err)
+		cfg = config.Default()
+	}
+
 	profile, err := store.LoadProfile()
 	if err != nil {
 		log.Fatalf("Failed to load profile: %v", err)
@@ -79,19 +127,82 @@ func NewApp(store *storage.Storage) *App {
 	feeds, err := store.LoadFeeds()
 	if err != nil {
 		log.Printf("Error loading feeds: %v", err)
-		feeds = []string{"https://lessnews.dev/rss.xml"}
+		feeds = []models.Feed{models.NewFeed("https://lessnews.dev/rss.xml")}
+	}
+
+	// Load cached articles so they are available before any network call
+	items, err := store.LoadArticles()
+	if err != nil {
+		log.Printf("Error loading cached articles: %v", err)
+		items = nil
+	}
+
+	feedMeta, err := store.LoadFeedMeta()
+	if err != nil {
+		log.Printf("Error loading feed metadata: %v", err)
+		feedMeta = make(map[string]models.FeedMeta)
+	}
+
+	theme, err := ui.ThemeByName(cfg.ThemeName())
+	if err != nil {
+		log.Printf("Error in theme config, using the dark theme: %v", err)
+		theme = ui.DarkTheme
+	}
+	ui.ApplyTheme(theme.WithAccent(cfg.Theme.AccentColor))
+
+	keys, err := ui.NewKeyMap(keyOverrides(cfg))
+	if err != nil {
+		log.Printf("Error in keyboard config, using default keys: %v", err)
+		keys = ui.DefaultKeyMap()
 	}
 
+	deduped := models.Dedupe(items)
+	index := search.NewIndex()
+	index.Update(deduped)
+
 	return &App{
-		store:   store,
-		profile: profile,
-		feeds:   feeds,
+		store:    store,
+		config:   cfg,
+		keys:     keys,
+		profile:  profile,
+		feeds:    feeds,
+		cached:   items,
+		items:    deduped,
+		index:    index,
+		feedMeta: feedMeta,
+		fetcher:  fetcher.New(fetcherOptions(cfg)),
+
+		lastRefresh: make(map[string]models.RefreshResult),
+	}
+}
+
+// fetcherOptions returns the request scheduling set in the config
+func fetcherOptions(cfg *config.Config) fetcher.Options {
+	options := fetcher.DefaultOptions()
+	options.Workers = cfg.Behavior.FetchWorkers
+	options.PerHost = cfg.Behavior.FetchPerHost
+	options.Timeout = time.Duration(cfg.Behavior.FetchTimeout)
+	return options
+}
+
+// keyOverrides collects the key bindings set in the config, keyed by action
+func keyOverrides(cfg *config.Config) map[ui.Action]string {
+	overrides := map[ui.Action]string{
+		ui.ActionNextPage:    cfg.Keyboard.NextPage,
+		ui.ActionPrevPage:    cfg.Keyboard.PrevPage,
+		ui.ActionOpenArticle: cfg.Keyboard.OpenArticle,
+		ui.ActionBack:        cfg.Keyboard.Back,
 	}
+	for name, key := range cfg.Keyboard.Custom {
+		overrides[ui.Action(name)] = key
+	}
+	return overrides
 }
 
 func (a *App) Run() {
 	// Initial feed refresh
-	a.refreshFeeds()
+	a.refreshFeeds(false)
+	a.startAutoRefresh(context.Background())
 
 	for {
 		a.showMainMenu()
@@ -101,14 +212,39 @@ func (a *App) Run() {
 func (a *App) showMainMenu() {
 	clearScreen()
 	fmt.Printf("%s v%s\n", ui.HeaderStyle.Render("RSS Reader"), Version)
+	items := a.articles()
+	fmt.Printf("%s %d unread of %d articles\n",
+		ui.DimStyle.Render("→"),
+		a.countUnread(items),
+		len(items))
+	if category := a.activeCategory(); category != "" {
+		fmt.Printf("%s %s\n", ui.DimStyle.Render("→ Category:"), ui.SourceStyle.Render(category))
+	}
+
+	a.mu.Lock()
+	newArticles := a.newArticles
+	refreshErr := a.refreshErr
+	a.mu.Unlock()
+	if newArticles > 0 {
+		fmt.Printf("%s %s\n",
+			ui.StatusStyle.Render(fmt.Sprintf("%d new articles", newArticles)),
+			ui.DimStyle.Render("press l to see the latest"))
+	}
+	if refreshErr != nil {
+		fmt.Printf("%s %s\n",
+			ui.ErrorStyle.Render("Background refresh failed:"),
+			ui.DimStyle.Render(refreshErr.Error()))
+	}
 
 	fmt.Println()
 	fmt.Println(ui.ArrowStyle.Render() + "Commands:")
 	fmt.Println(ui.ArrowStyle.Render())
+	fmt.Printf("%s (l)atest       Browse latest articles\n", ui.ArrowStyle.Render())
 	fmt.Printf("%s (s)earch       Search articles\n", ui.ArrowStyle.Render())
 	fmt.Printf("%s (r)ecommended  View recommended articles\n", ui.ArrowStyle.Render())
 	fmt.Printf("%s (i)nterests    Manage your interests\n", ui.ArrowStyle.Render())
 	fmt.Printf("%s (f)eeds        Manage RSS feeds\n", ui.ArrowStyle.Render())
+	fmt.Printf("%s (c)ategory     Show one category only\n", ui.ArrowStyle.Render())
 	fmt.Printf("%s refre(x)h      Update all feeds\n", ui.ArrowStyle.Render())
 	fmt.Printf("%s (q)uit         Exit the application\n", ui.ArrowStyle.Render())
 	fmt.Printf("%s (h)elp         Show help\n", ui.ArrowStyle.Render())
@@ -127,6 +263,9 @@ func (a *App) handleCommand(cmd string) {
 	case "h", "help":
 		a.showMainHelp()
 		return
+	case "l", "latest":
+		a.showLatest()
+		return
 	case "s", "search":
 		a.searchArticles()
 		return
@@ -139,12 +278,15 @@ func (a *App) handleCommand(cmd string) {
 	case "f", "feeds":
 		a.manageFeeds()
 		return
+	case "c", "category":
+		a.chooseCategory()
+		return
 	case "x", "refresh":
 		if !confirmAction("Are you sure you want to refresh all feeds? This may take a while.") {
 			fmt.Println(ui.DimStyle.Render("Operation cancelled"))
 			return
 		}
-		a.refreshFeeds()
+		a.refreshFeeds(true)
 		return
 	case "q", "quit", "exit":
 		os.Exit(0)
@@ -154,28 +296,363 @@ func (a *App) handleCommand(cmd string) {
 	}
 }
 
-func (a *App) refreshFeeds() {
-	stop := showProgress("Updating feeds")
-	defer stop()
+// refreshFeeds refreshes the feeds in the foreground. Unless force is set,
+// feeds whose refresh interval has not passed yet are skipped.
+func (a *App) refreshFeeds(force bool) {
+	ctx, cancel := context.WithCancel(context.Background())
+	a.refreshMu.Lock()
+	a.cancelRefresh = cancel
+	a.refreshMu.Unlock()
+	defer func() {
+		a.refreshMu.Lock()
+		a.cancelRefresh = nil
+		a.refreshMu.Unlock()
+		cancel()
+	}()
+
+	// A background refresh would hold the lock until it finishes; stop it
+	// instead, keeping the feeds it already fetched
+	a.refreshMu.Lock()
+	if a.cancelBackground != nil {
+		a.cancelBackground()
+	}
+	a.refreshMu.Unlock()
+
+	stop := showProgress("Updating feeds (Ctrl-C to cancel)")
+	results, _, err := a.fetchFeeds(ctx, force)
+	stop()
+
+	if err != nil {
+		showError("Failed to save articles: " + err.Error())
+	} else {
+		a.mu.Lock()
+		a.refreshErr = nil
+		a.mu.Unlock()
+	}
+	a.showRefreshSummary(results, ctx.Err() != nil)
+}
+
+// cancelActiveRefresh cancels the refresh in progress and reports whether
+// there was one
+func (a *App) cancelActiveRefresh() bool {
+	a.refreshMu.Lock()
+	defer a.refreshMu.Unlock()
+	if a.cancelRefresh == nil {
+		return false
+	}
+	a.cancelRefresh()
+	return true
+}
+
+// startAutoRefresh refreshes feeds in the background every
+// Behavior.AutoRefreshInterval until ctx is cancelled
+func (a *App) startAutoRefresh(ctx context.Context) {
+	interval := time.Duration(a.config.Behavior.AutoRefreshInterval)
+	if interval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				// Skip this tick if a manual refresh is already running
+				if !a.fetchMu.TryLock() {
+					continue
+				}
+				_, added, err := a.backgroundRefresh(ctx)
+				a.fetchMu.Unlock()
+
+				// Logging would garble the screen, so the main menu shows it
+				a.mu.Lock()
+				a.newArticles += added
+				a.refreshErr = err
+				a.mu.Unlock()
+			}
+		}
+	}()
+}
+
+// backgroundRefresh runs fetchFeedsLocked with a context that a foreground
+// refresh can cancel
+func (a *App) backgroundRefresh(ctx context.Context) ([]models.RefreshResult, int, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	a.refreshMu.Lock()
+	a.cancelBackground = cancel
+	a.refreshMu.Unlock()
+	defer func() {
+		a.refreshMu.Lock()
+		a.cancelBackground = nil
+		a.refreshMu.Unlock()
+		cancel()
+	}()
+
+	return a.fetchFeedsLocked(ctx, false)
+}
+
+// fetchFeeds fetches every enabled feed that is due, or every enabled feed
+// when force is set, and merges new items into the article cache. It returns
+// the outcome for each feed, fetched feeds first and skipped feeds last, and
+// the number of articles added. Items from feeds that finished before ctx was
+// cancelled are still merged. Safe to call from a background goroutine.
+func (a *App) fetchFeeds(ctx context.Context, force bool) ([]models.RefreshResult, int, error) {
+	a.fetchMu.Lock()
+	defer a.fetchMu.Unlock()
+	return a.fetchFeedsLocked(ctx, force)
+}
+
+// fetchFeedsLocked is fetchFeeds for callers holding fetchMu
+func (a *App) fetchFeedsLocked(ctx context.Context, force bool) ([]models.RefreshResult, int, error) {
+	a.mu.Lock()
+	// Only send conditional headers for feeds with cached items to fall back on
+	cachedFeeds := make(map[string]bool)
+	for _, item := range a.cached {
+		cachedFeeds[item.FeedURL] = true
+	}
+
+	now := time.Now()
+	var jobs []fetcher.Job
+	var skipped []models.RefreshResult
+	for _, feed := range a.feeds {
+		meta := a.feedMeta[feed.URL]
+		if !feed.Enabled || !(force || feed.Due(meta, now)) {
+			skipped = append(skipped, models.RefreshResult{URL: feed.URL, Status: models.RefreshSkipped})
+			continue
+		}
+
+		job := fetcher.Job{URL: feed.URL, Meta: meta}
+		if !cachedFeeds[feed.URL] {
+			job.Meta.ETag = ""
+			job.Meta.LastModified = ""
+		}
+		jobs = append(jobs, job)
+	}
+	a.mu.Unlock()
+
+	outcomes := a.fetcher.FetchAll(ctx, jobs)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	var items []models.FeedItem
+	results := make([]models.RefreshResult, len(jobs), len(jobs)+len(skipped))
+	for i, outcome := range outcomes {
+		result := models.RefreshResult{
+			URL:      outcome.URL,
+			Duration: outcome.Duration,
+		}
+		if outcome.Result != nil {
+			result.StatusCode = outcome.Result.Meta.LastStatus
+			a.feedMeta[outcome.URL] = outcome.Result.Meta
+		}
+
+		switch {
+		case outcome.Err != nil:
+			result.Status = models.RefreshFailed
+			result.Err = outcome.Err
+		case outcome.Result.NotModified:
+			// The cached items for this feed are already in a.cached
+			result.Status = models.RefreshNotModified
+		default:
+			// Use the settings as they are now, in case they changed while
+			// the request was in flight
+			feed := a.feed(outcome.URL)
+			feedItems := parseFeed(feed, outcome.Result.Feed)
+			result.Status = models.RefreshOK
+			result.ItemCount = len(feedItems)
+			items = append(items, feedItems...)
+		}
+		results[i] = result
+		a.lastRefresh[result.URL] = result
+	}
+	results = append(results, skipped...)
+
+	if err := a.store.SaveFeedMeta(a.feedMeta); err != nil {
+		log.Printf("Error saving feed metadata: %v", err)
+	}
+
+	// Merge into the cache so items rotated out of a feed are kept
+	before := a.items
+	a.cached = storage.MergeArticles(a.cached, items)
+	a.cached = storage.LimitArticles(a.cached, a.articleLimit)
+	a.items = models.Dedupe(a.cached)
+	a.index.Update(a.items)
+	added := models.CountNew(before, a.items)
+
+	if err := a.store.SaveArticles(a.cached); err != nil {
+		return results, added, err
+	}
+
+	return results, added, nil
+}
+
+// articles returns the articles to show, limited to the active category
+func (a *App) articles() []models.FeedItem {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.category == "" {
+		return a.items
+	}
 
-	var wg sync.WaitGroup
-	var mu sync.Mutex
+	inCategory := make(map[string]bool)
+	for _, feed := range a.feeds {
+		if feed.Category == a.category {
+			inCategory[feed.URL] = true
+		}
+	}
+	// Filter before merging so an article shared with another category is kept
 	var items []models.FeedItem
+	for _, item := range a.cached {
+		if inCategory[item.FeedURL] {
+			items = append(items, item)
+		}
+	}
+	return models.Dedupe(items)
+}
+
+func (a *App) activeCategory() string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.category
+}
+
+// chooseCategory sets the category that article lists and recommendations
+// are limited to
+func (a *App) chooseCategory() {
+	a.mu.Lock()
+	categories := models.Categories(a.feeds)
+	a.mu.Unlock()
+
+	if len(categories) == 0 {
+		showError("No categories yet. Assign feeds to categories in Manage Feeds")
+		return
+	}
+
+	clearScreen()
+	fmt.Println(ui.HeaderStyle.Render("Categories"))
+	fmt.Println()
+	fmt.Printf("%s 0. All categories\n", ui.ArrowStyle.Render())
+	for i, category := range categories {
+		fmt.Printf("%s %d. %s\n", ui.ArrowStyle.Render(), i+1, category)
+	}
+	fmt.Println()
+	fmt.Print(ui.CommandStyle.Render("Enter category number: "))
+
+	index, err := strconv.Atoi(strings.TrimSpace(readLine()))
+	if err != nil || index < 0 || index > len(categories) {
+		showError("Invalid category number")
+		return
+	}
+
+	a.mu.Lock()
+	if index == 0 {
+		a.category = ""
+	} else {
+		a.category = categories[index-1]
+	}
+	a.mu.Unlock()
+
+	if index == 0 {
+		showSuccess("Showing all categories")
+	} else {
+		showSuccess("Showing only " + categories[index-1])
+	}
+}
+
+// feed returns the settings of a subscribed feed. The caller must hold a.mu.
+func (a *App) feed(feedURL string) models.Feed {
+	for _, feed := range a.feeds {
+		if feed.URL == feedURL {
+			return feed
+		}
+	}
+	return models.NewFeed(feedURL)
+}
+
+// articleLimit returns how many articles of a feed are kept; 0 keeps all
+func (a *App) articleLimit(feedURL string) int {
+	if limit := a.feed(feedURL).MaxItems; limit > 0 {
+		return limit
+	}
+	return a.config.Behavior.MaxArticlesPerFeed
+}
+
+func (a *App) metaFor(feedURL string) models.FeedMeta {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.feedMeta[feedURL]
+}
+
+func (a *App) refreshResult(feedURL string) (models.RefreshResult, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	result, ok := a.lastRefresh[feedURL]
+	return result, ok
+}
+
+func (a *App) showRefreshSummary(results []models.RefreshResult, cancelled bool) {
+	clearScreen()
+	fmt.Println(ui.HeaderStyle.Render("Refresh Summary"))
+	fmt.Println()
+
+	var failed, skipped, newItems int
+	for _, result := range results {
+		if result.Status == models.RefreshSkipped {
+			skipped++
+			continue
+		}
+		fmt.Printf("%s %s %s\n", ui.ArrowStyle.Render(), refreshIcon(result), result.URL)
+		fmt.Printf("    %s\n", formatRefreshResult(result))
+		if result.Status == models.RefreshFailed {
+			failed++
+		}
+		newItems += result.ItemCount
+	}
+
+	fmt.Println()
+	if cancelled {
+		fmt.Println(ui.ErrorStyle.Render("Refresh cancelled"))
+	} else if failed > 0 {
+		fmt.Println(ui.ErrorStyle.Render(fmt.Sprintf("%d of %d feeds failed to update", failed, len(results)-skipped)))
+	} else {
+		fmt.Println(ui.SuccessStyle.Render("Feeds updated successfully"))
+	}
+	fmt.Printf("%s %d items fetched, %d articles shown\n", ui.DimStyle.Render("→"), newItems, len(a.articles()))
+	if skipped > 0 {
+		fmt.Printf("%s %d feeds skipped (paused or not due yet)\n", ui.DimStyle.Render("→"), skipped)
+	}
+	fmt.Println()
+	fmt.Print(ui.CommandStyle.Render("Press Enter to continue..."))
+	readLine()
+}
 
-	for _, feedURL := range a.feeds {
-		wg.Add(1)
-		go func(url string) {
-			defer wg.Done()
-			feed := parseFeed(url)
-			mu.Lock()
-			items = append(items, feed...)
-			mu.Unlock()
-		}(feedURL)
+// refreshIcon returns a short styled marker for a refresh result
+func refreshIcon(result models.RefreshResult) string {
+	switch result.Status {
+	case models.RefreshOK:
+		return ui.SuccessStyle.Render("✓")
+	case models.RefreshNotModified:
+		return ui.DimStyle.Render("=")
+	default:
+		return ui.ErrorStyle.Render("✗")
 	}
-	wg.Wait()
+}
 
-	a.items = items
-	showSuccess("Feeds updated successfully")
+// formatRefreshResult describes a refresh result on a single line
+func formatRefreshResult(result models.RefreshResult) string {
+	duration := result.Duration.Round(time.Millisecond)
+	switch result.Status {
+	case models.RefreshOK:
+		return ui.DimStyle.Render(fmt.Sprintf("HTTP %d · %d items · %s", result.StatusCode, result.ItemCount, duration))
+	case models.RefreshNotModified:
+		return ui.DimStyle.Render(fmt.Sprintf("HTTP %d · not modified, using cached items · %s", result.StatusCode, duration))
+	default:
+		return ui.ErrorStyle.Render(fmt.Sprintf("%v (%s)", result.Err, duration))
+	}
 }
 
 func (a *App) searchArticles() {
@@ -207,63 +684,179 @@ func (a *App) searchArticles() {
 	a.showSearchResults(query, results)
 }
 
-func (a *App) searchItems(query string) []models.FeedItem {
-	query = strings.ToLower(query)
-	var results []models.FeedItem
+// searchItems ranks the articles in the active category against the query,
+// best match first
+func (a *App) searchItems(query string) []models.SearchResult {
+	a.mu.Lock()
+	results := a.index.Search(query)
+	category := a.category
+	a.mu.Unlock()
+
+	if category == "" {
+		return results
+	}
+
+	// The index holds articles merged across every feed; keep those shown
+	// in the category, as they are merged there
+	byKey := make(map[string]models.FeedItem)
+	byLink := make(map[string]models.FeedItem)
+	for _, item := range a.articles() {
+		byKey[item.Key()] = item
+		if item.Link != "" {
+			byLink[models.CanonicalURL(item.Link)] = item
+		}
+	}
 
-	for _, item := range a.items {
-		if strings.Contains(strings.ToLower(item.Title), query) ||
-			strings.Contains(strings.ToLower(item.Description), query) {
-			results = append(results, item)
+	var filtered []models.SearchResult
+	for _, result := range results {
+		item, ok := byKey[result.Item.Key()]
+		if !ok && result.Item.Link != "" {
+			item, ok = byLink[models.CanonicalURL(result.Item.Link)]
+		}
+		if ok {
+			result.Item = item
+			filtered = append(filtered, result)
 		}
 	}
+	return filtered
+}
+
+func (a *App) showSearchResults(query string, results []models.SearchResult) {
+	items := make([]models.FeedItem, len(results))
+	matches := make(map[string]models.SearchResult, len(results))
+	for i, result := range results {
+		items[i] = result.Item
+		matches[result.Item.Key()] = result
+	}
+	a.showMatchedArticleList(fmt.Sprintf("Search Results for \"%s\"", query), items, matches)
+}
+
+// showLatest lists all cached articles, newest first
+func (a *App) showLatest() {
+	a.mu.Lock()
+	a.newArticles = 0
+	a.mu.Unlock()
+
+	articles := a.articles()
+	if len(articles) == 0 {
+		showError("No articles yet. Refresh feeds first!")
+		return
+	}
 
-	return results
+	items := make([]models.FeedItem, len(articles))
+	copy(items, articles)
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].Published.After(items[j].Published) // Newer first
+	})
+
+	a.showArticleList("Latest Articles", items)
+}
+
+// showArticleList shows a paginated, selectable list of articles
+func (a *App) showArticleList(title string, items []models.FeedItem) {
+	a.showMatchedArticleList(title, items, nil)
 }
 
-func (a *App) showSearchResults(query string, results []models.FeedItem) {
+// showMatchedArticleList is showArticleList for search results: articles
+// with an entry in matches, keyed by item key, are shown with their score,
+// an excerpt and the matched words highlighted
+func (a *App) showMatchedArticleList(title string, items []models.FeedItem, matches map[string]models.SearchResult) {
 	currentPage := 0
-	itemsPerPage := 10
-	totalPages := (len(results) + itemsPerPage - 1) / itemsPerPage
+	itemsPerPage := a.config.Behavior.DefaultPageSize
 	selectedItem := 0
+	unreadOnly := false
 
 	for {
+		// Filter on every pass so read state changes are reflected immediately
+		results := items
+		if unreadOnly {
+			results = a.filterUnread(items)
+		}
+
+		totalPages := max((len(results)+itemsPerPage-1)/itemsPerPage, 1)
+		if currentPage >= totalPages {
+			currentPage = totalPages - 1
+			selectedItem = 0
+		}
+
 		clearScreen()
-		fmt.Printf("%s Search Results for \"%s\"\n", ui.HeaderStyle.Render("→"), query)
-		fmt.Printf("%s Found %d articles\n", ui.DimStyle.Render("→"), len(results))
+		fmt.Printf("%s %s\n", ui.HeaderStyle.Render("→"), title)
+		fmt.Printf("%s Found %d articles (%d unread)\n", ui.DimStyle.Render("→"), len(items), a.countUnread(items))
+		if unreadOnly {
+			fmt.Printf("%s Showing unread only\n", ui.ArrowStyle.Render())
+		}
 		fmt.Println()
 
 		// Display results for current page
 		start := currentPage * itemsPerPage
 		end := min(start+itemsPerPage, len(results))
+		if selectedItem > max(end-start-1, 0) {
+			selectedItem = max(end-start-1, 0)
+		}
+
+		if len(results) == 0 {
+			if unreadOnly {
+				fmt.Println(ui.DimStyle.Render("No unread articles"))
+			} else {
+				fmt.Println(ui.DimStyle.Render("No articles"))
+			}
+			fmt.Println()
+		}
 
 		for i, item := range results[start:end] {
 			cursor := ui.UnselectedStyle.Render()
 			if i == selectedItem {
 				cursor = ui.SelectedStyle.Render()
 			}
-			fmt.Printf("%s %s. %s\n",
+			match, matched := matches[item.Key()]
+			if a.config.Display.CompactView {
+				itemTitle := ui.TextStyle.Render(item.Title)
+				detail := ui.DimStyle.Render("— "+item.SourceLabel()+" · ") + a.formatItemDate(item)
+				if matched {
+					itemTitle = ui.Highlight(item.Title, search.MatchSpans(item.Title, match.Matches), ui.TextStyle)
+					detail = ui.ScoreStyle.Render(fmt.Sprintf("%.2f", match.Score)) + ui.DimStyle.Render(" ") + detail
+				}
+				fmt.Printf("%s %s %s. %s %s\n",
+					cursor,
+					a.readMarker(item),
+					ui.DimStyle.Render(fmt.Sprintf("%d", start+i+1)),
+					itemTitle,
+					detail)
+				continue
+			}
+			itemTitle := ui.TitleStyle.Render(item.Title)
+			if matched {
+				itemTitle = ui.Highlight(item.Title, search.MatchSpans(item.Title, match.Matches), ui.TitleStyle)
+			}
+			fmt.Printf("%s %s %s. %s\n",
 				cursor,
+				a.readMarker(item),
 				ui.DimStyle.Render(fmt.Sprintf("%d", start+i+1)),
-				ui.TitleStyle.Render(item.Title))
+				itemTitle)
 			fmt.Printf("   %s - %s\n",
-				ui.SourceStyle.Render(item.FeedSource),
-				ui.DateStyle.Render(item.Published.Format("2006-01-02")))
+				ui.SourceStyle.Render(item.SourceLabel()),
+				a.formatItemDate(item))
+			if matched {
+				fmt.Printf("   %s %.2f\n",
+					ui.DimStyle.Render("Score:"),
+					match.Score)
+				if excerpt := search.Snippet(item.Description, match.Matches, 100); excerpt != "" {
+					fmt.Printf("   %s\n", ui.Highlight(excerpt, search.MatchSpans(excerpt, match.Matches), ui.DimStyle))
+				}
+			}
 			fmt.Printf("   %s %s\n",
 				ui.DimStyle.Render("Link:"),
 				ui.LinkStyle.Render(item.Link))
 			fmt.Println()
 		}
 
+		// Show pagination info
+		fmt.Printf("%s Page %d of %d\n", ui.ArrowStyle.Render(), currentPage+1, totalPages)
+
 		// Show navigation help
 		fmt.Println()
 		fmt.Println(ui.DimStyle.Render("Navigation:"))
-		fmt.Printf("%s ↑/↓          Navigate items\n", ui.ArrowStyle.Render())
-		fmt.Printf("%s ←/→          Change pages\n", ui.ArrowStyle.Render())
-		fmt.Printf("%s Enter         View selected article\n", ui.ArrowStyle.Render())
-		fmt.Printf("%s o             Open in browser\n", ui.ArrowStyle.Render())
-		fmt.Printf("%s b             Back to main menu\n", ui.ArrowStyle.Render())
-		fmt.Printf("%s h             Show help\n", ui.ArrowStyle.Render())
+		a.printBindings(ui.ScreenList)
 		fmt.Println()
 
 		// Read key input
@@ -272,79 +865,71 @@ func (a *App) showSearchResults(query string, results []models.FeedItem) {
 			continue
 		}
 
-		// Handle 'o' followed by number
-		if key.key == 'o' {
-			var numStr string
-			fmt.Print("o") // Show the 'o' being typed
-
-			// Read subsequent digits
-			for {
-				k, err := readKey()
-				if err != nil {
-					break
-				}
-				// If Enter is pressed or non-digit/non-backspace, break
-				if k.key == 13 || (k.key != 127 && (k.key < '0' || k.key > '9')) {
-					break
-				}
-				// If backspace, remove last digit
-				if k.key == 127 && len(numStr) > 0 {
-					numStr = numStr[:len(numStr)-1]
-					fmt.Print("\b \b") // Erase character
-					continue
-				}
-				// Add digit and show it
-				numStr += string(k.char)
-				fmt.Print(string(k.char))
-			}
-			fmt.Println() // New line after input
-
-			// Process the number
-			if numStr != "" {
-				if num, err := strconv.Atoi(numStr); err == nil {
-					index := num - 1
-					if index >= 0 && index < len(results) {
-						if err := openInBrowser(results[index].Link); err != nil {
-							showError("Failed to open browser")
-						} else {
-							showSuccess(fmt.Sprintf("Opened article %d in browser", num))
-						}
-					} else {
-						showError(fmt.Sprintf("Invalid article number: %d", num))
-					}
-				}
-			}
-			continue
-		}
-
+		// Arrow keys and Enter are fixed; everything else goes through the keymap
+		action, _ := a.keys.Lookup(ui.ScreenList, string(key.char))
 		switch key.key {
 		case 'B': // Down arrow
 			if selectedItem < min(itemsPerPage-1, end-start-1) {
 				selectedItem++
 			}
+			continue
 		case 'A': // Up arrow
 			if selectedItem > 0 {
 				selectedItem--
 			}
+			continue
 		case 'C': // Right arrow
+			action = ui.ActionNextPage
+		case 'D': // Left arrow
+			action = ui.ActionPrevPage
+		case 13: // Enter
+			action = ui.ActionView
+		}
+
+		switch action {
+		case ui.ActionOpenArticle:
+			// Open key followed by an article number
+			if num, ok := readArticleNumber(string(key.char)); ok {
+				index := num - 1
+				if index >= 0 && index < len(results) {
+					if err := a.openArticle(results[index]); err != nil {
+						showError("Failed to open browser")
+					} else {
+						showSuccess(fmt.Sprintf("Opened article %d in browser", num))
+					}
+				} else {
+					showError(fmt.Sprintf("Invalid article number: %d", num))
+				}
+			}
+		case ui.ActionNextPage:
 			if currentPage < totalPages-1 {
 				currentPage++
 				selectedItem = 0
 			}
-		case 'D': // Left arrow
+		case ui.ActionPrevPage:
 			if currentPage > 0 {
 				currentPage--
 				selectedItem = 0
 			}
-		case 'h': // Help
-			a.showSearchHelp()
-			continue
-		case 13: // Enter
+		case ui.ActionHelp:
+			a.showListHelp()
+		case ui.ActionView:
 			itemIndex := start + selectedItem
 			if itemIndex < len(results) {
 				a.viewArticleSequence(results, itemIndex)
 			}
-		case 'b':
+		case ui.ActionToggleRead:
+			itemIndex := start + selectedItem
+			if itemIndex < len(results) {
+				a.toggleRead(results[itemIndex])
+			}
+		case ui.ActionMarkAllRead:
+			a.markAllRead(results)
+		case ui.ActionUnreadOnly:
+			unreadOnly = !unreadOnly
+			currentPage = 0
+			selectedItem = 0
+		case ui.ActionBack:
 			return
 		}
 	}
@@ -386,18 +971,7 @@ func (a *App) showRecommendations() {
 		return
 	}
 
-	// Calculate recommendations
-	var recommendations []models.ArticleScore
-	for _, item := range a.items {
-		score := a.calculateInterestScore(item)
-		if score > 0 {
-			recommendations = append(recommendations, models.ArticleScore{
-				Item:  item,
-				Score: score,
-			})
-		}
-	}
-
+	recommendations := a.recommendations()
 	if len(recommendations) == 0 {
 		showError("No recommendations found")
 		return
@@ -405,14 +979,17 @@ func (a *App) showRecommendations() {
 
 	// Initialize variables
 	currentPage := 0
-	itemsPerPage := 10
+	itemsPerPage := a.config.Behavior.DefaultPageSize
 	selectedItem := 0
 	sortBy := SortByScore // Track current sort mode
 
 	for {
 		clearScreen()
 		fmt.Println(ui.HeaderStyle.Render("Recommended Articles"))
-		fmt.Printf("%s Found %d recommendations\n", ui.DimStyle.Render("→"), len(recommendations))
+		fmt.Printf("%s Found %d recommendations (%d unread)\n",
+			ui.DimStyle.Render("→"),
+			len(recommendations),
+			a.countUnread(articleItems(recommendations)))
 		sortMode := "Relevance"
 		if sortBy == SortByDate {
 			sortMode = "Date"
@@ -436,13 +1013,23 @@ func (a *App) showRecommendations() {
 			if i == selectedItem {
 				cursor = ui.SelectedStyle.Render()
 			}
-			fmt.Printf("%s %s. %s\n",
+			if a.config.Display.CompactView {
+				fmt.Printf("%s %s %s. %s %s\n",
+					cursor,
+					a.readMarker(article.Item),
+					ui.DimStyle.Render(fmt.Sprintf("%d", start+i+1)),
+					ui.TextStyle.Render(article.Item.Title),
+					ui.ScoreStyle.Render(fmt.Sprintf("%.2f", article.Score))+ui.DimStyle.Render(" — "+article.Item.SourceLabel()+" · ")+a.formatItemDate(article.Item))
+				continue
+			}
+			fmt.Printf("%s %s %s. %s\n",
 				cursor,
+				a.readMarker(article.Item),
 				ui.DimStyle.Render(fmt.Sprintf("%d", start+i+1)),
 				ui.TitleStyle.Render(article.Item.Title))
 			fmt.Printf("   %s - %s\n",
-				ui.SourceStyle.Render(article.Item.FeedSource),
-				ui.DateStyle.Render(article.Item.Published.Format("2006-01-02")))
+				ui.SourceStyle.Render(article.Item.SourceLabel()),
+				a.formatItemDate(article.Item))
 			fmt.Printf("   %s %.2f\n",
 				ui.DimStyle.Render("Score:"),
 				article.Score)
@@ -459,13 +1046,7 @@ func (a *App) showRecommendations() {
 
 		// Show commands
 		fmt.Println(ui.ArrowStyle.Render() + "Commands:")
-		fmt.Printf("%s ↑/↓          Navigate items\n", ui.ArrowStyle.Render())
-		fmt.Printf("%s ←/→          Change pages\n", ui.ArrowStyle.Render())
-		fmt.Printf("%s (s)ort       Toggle sort (relevance/date)\n", ui.ArrowStyle.Render())
-		fmt.Printf("%s (v)iew       View article details\n", ui.ArrowStyle.Render())
-		fmt.Printf("%s (o)[number]  Open in browser\n", ui.ArrowStyle.Render())
-		fmt.Printf("%s (b)ack       Return to main menu\n", ui.ArrowStyle.Render())
-		fmt.Printf("%s (h)elp       Show help\n", ui.ArrowStyle.Render())
+		a.printBindings(ui.ScreenRecommendations)
 		fmt.Println()
 
 		// Handle keyboard input
@@ -474,91 +1055,59 @@ func (a *App) showRecommendations() {
 			continue
 		}
 
-		// Handle 'o' followed by number
-		if key.key == 'o' {
-			var numStr string
-			fmt.Print("o") // Show the 'o' being typed
-
-			// Read subsequent digits
-			for {
-				k, err := readKey()
-				if err != nil {
-					break
-				}
-				// If Enter is pressed or non-digit/non-backspace, break
-				if k.key == 13 || (k.key != 127 && (k.key < '0' || k.key > '9')) {
-					break
-				}
-				// If backspace, remove last digit
-				if k.key == 127 && len(numStr) > 0 {
-					numStr = numStr[:len(numStr)-1]
-					fmt.Print("\b \b") // Erase character
-					continue
-				}
-				// Add digit and show it
-				numStr += string(k.char)
-				fmt.Print(string(k.char))
-			}
-			fmt.Println() // New line after input
-
-			// Process the number
-			if numStr != "" {
-				if num, err := strconv.Atoi(numStr); err == nil {
-					index := num - 1
-					if index >= 0 && index < len(sorted) {
-						if err := openInBrowser(sorted[index].Item.Link); err != nil {
-							showError("Failed to open browser")
-						} else {
-							showSuccess(fmt.Sprintf("Opened article %d in browser", num))
-						}
-					} else {
-						showError(fmt.Sprintf("Invalid article number: %d", num))
-					}
-				}
-			}
-			continue
-		}
-
-		// Handle navigation
+		// Arrow keys and Enter are fixed; everything else goes through the keymap
+		action, _ := a.keys.Lookup(ui.ScreenRecommendations, string(key.char))
 		switch key.key {
 		case 'B': // Down arrow
 			if selectedItem < min(itemsPerPage-1, end-start-1) {
 				selectedItem++
 			}
+			continue
 		case 'A': // Up arrow
 			if selectedItem > 0 {
 				selectedItem--
 			}
+			continue
 		case 'C': // Right arrow
-			if currentPage < totalPages-1 {
-				currentPage++
-				selectedItem = 0
-			} else {
-				showError("Already on last page")
-			}
+			action = ui.ActionNextPage
 		case 'D': // Left arrow
-			if currentPage > 0 {
-				currentPage--
-				selectedItem = 0
+			action = ui.ActionPrevPage
+		case 13: // Enter
+			action = ui.ActionView
+		}
+
+		switch action {
+		case ui.ActionOpenArticle:
+			// Open key followed by an article number
+			if num, ok := readArticleNumber(string(key.char)); ok {
+				index := num - 1
+				if index >= 0 && index < len(sorted) {
+					if err := a.openArticle(sorted[index].Item); err != nil {
+						showError("Failed to open browser")
+					} else {
+						showSuccess(fmt.Sprintf("Opened article %d in browser", num))
+					}
+				} else {
+					showError(fmt.Sprintf("Invalid article number: %d", num))
+				}
 			}
-		case 'h': // Help
+		case ui.ActionHelp:
 			a.showRecommendationsHelp()
-			continue
-		case 'n': // Next page
+		case ui.ActionNextPage:
 			if currentPage < totalPages-1 {
 				currentPage++
 				selectedItem = 0
 			} else {
 				showError("Already on last page")
 			}
-		case 'p': // Previous page
+		case ui.ActionPrevPage:
 			if currentPage > 0 {
 				currentPage--
 				selectedItem = 0
 			} else {
 				showError("Already on first page")
 			}
-		case 's': // Sort
+		case ui.ActionSort:
 			if sortBy == SortByScore {
 				sortBy = SortByDate
 				fmt.Println(ui.SuccessStyle.Render("Sorting by date"))
@@ -567,70 +1116,42 @@ func (a *App) showRecommendations() {
 				fmt.Println(ui.SuccessStyle.Render("Sorting by relevance"))
 			}
 			time.Sleep(1 * time.Second)
-			continue
-		case 'v', 13: // View or Enter
+		case ui.ActionView:
 			itemIndex := start + selectedItem
 			if itemIndex < len(sorted) {
-				// Convert ArticleScore slice to FeedItem slice
-				items := make([]models.FeedItem, len(sorted))
-				for i, score := range sorted {
-					items[i] = score.Item
-				}
-				a.viewArticleSequence(items, itemIndex)
-			}
-		case 'b': // Back
-			return
-		case 'e': // Export to Google Sheets
-			stop := showProgress("Exporting to Google Sheets")
-			result := a.exportToSheets(sorted)
-			stop()
-
-			if result.Error != nil {
-				clearScreen()
-				fmt.Println(ui.HeaderStyle.Render("Export Error"))
-				fmt.Println()
-				fmt.Println(ui.ErrorStyle.Render(result.Error.Error()))
-				fmt.Println()
-				fmt.Print(ui.CommandStyle.Render("Press Enter to continue..."))
-				readLine()
-				return
-			}
-
-			clearScreen()
-			fmt.Println(ui.HeaderStyle.Render("Export Success"))
-			fmt.Println()
-			fmt.Println(ui.SuccessStyle.Render("Articles exported successfully!"))
-			fmt.Println()
-			fmt.Println(ui.DimStyle.Render("Spreadsheet URL:"))
-			fmt.Printf("\033]8;;%s\033\\%s\033]8;;\033\\\n", result.URL, ui.LinkStyle.Render(result.URL))
-			fmt.Println()
-			fmt.Println(ui.DimStyle.Render("Commands:"))
-			fmt.Printf("%s (o)pen    Open spreadsheet in browser\n", ui.ArrowStyle.Render())
-			fmt.Printf("%s (b)ack    Return to recommendations\n", ui.ArrowStyle.Render())
-			fmt.Println()
-
-			// Wait for command
-			key, err := readKey()
-			if err != nil {
-				return
+				a.viewArticleSequence(articleItems(sorted), itemIndex)
 			}
-
-			switch key.key {
-			case 'o':
-				if err := openInBrowser(result.URL); err != nil {
-					showError("Failed to open browser")
-				} else {
-					showSuccess("Opened spreadsheet in browser")
-				}
-			case 'b':
-				return
+		case ui.ActionToggleRead:
+			itemIndex := start + selectedItem
+			if itemIndex < len(sorted) {
+				a.toggleRead(sorted[itemIndex].Item)
 			}
+		case ui.ActionMarkAllRead:
+			a.markAllRead(articleItems(sorted))
+		case ui.ActionBack:
 			return
+		case ui.ActionExport:
+			a.exportArticles(sorted)
 		}
 	}
 }
 
-// Add this helper function for sorting articles
+// recommendations scores every article against the user's interests and
+// returns those that match at all, unsorted
+func (a *App) recommendations() []models.ArticleScore {
+	var recommendations []models.ArticleScore
+	for _, item := range a.articles() {
+		score := a.calculateInterestScore(item)
+		if score > 0 {
+			recommendations = append(recommendations, models.ArticleScore{
+				Item:  item,
+				Score: score,
+			})
+		}
+	}
+	return recommendations
+}
+
 func (a *App) sortRecommendations(articles []models.ArticleScore, sortBy int) []models.ArticleScore {
 	sorted := make([]models.ArticleScore, len(articles))
 	copy(sorted, articles)
@@ -654,14 +1175,7 @@ func (a *App) showRecommendationsHelp() {
 	fmt.Println(ui.HeaderStyle.Render("Recommendations Help"))
 	fmt.Println()
 	fmt.Println(ui.DimStyle.Render("Commands:"))
-	fmt.Printf("%s ↑/↓          Navigate items\n", ui.ArrowStyle.Render())
-	fmt.Printf("%s ←/→          Change pages\n", ui.ArrowStyle.Render())
-	fmt.Printf("%s (s)ort       Toggle sort (relevance/date)\n", ui.ArrowStyle.Render())
-	fmt.Printf("%s (v)iew       View article details\n", ui.ArrowStyle.Render())
-	fmt.Printf("%s (o)[number]  Open in browser\n", ui.ArrowStyle.Render())
-	fmt.Printf("%s (e)xport     Export to Google Sheets\n", ui.ArrowStyle.Render())
-	fmt.Printf("%s (b)ack       Return to main menu\n", ui.ArrowStyle.Render())
-	fmt.Printf("%s (h)elp       Show this help\n", ui.ArrowStyle.Render())
+	a.printBindings(ui.ScreenRecommendations)
 	fmt.Println()
 	fmt.Print(ui.CommandStyle.Render("Press Enter to continue..."))
 	readLine()
@@ -826,119 +1340,565 @@ func (a *App) manageInterests() {
 	}
 }
 
-func (a *App) manageFeeds() {
+func (a *App) manageFeeds() {
+	for {
+		clearScreen()
+		fmt.Println(ui.HeaderStyle.Render("Manage Feeds"))
+		fmt.Println()
+
+		for i, feed := range a.feeds {
+			label := ""
+			if feed.Category != "" {
+				label += " " + ui.SourceStyle.Render("["+feed.Category+"]")
+			}
+			if feed.Aggregator {
+				label += " " + ui.DimStyle.Render("[aggregator]")
+			}
+			if !feed.Enabled {
+				label += " " + ui.ErrorStyle.Render("[paused]")
+			}
+			name := feed.DisplayTitle(a.metaFor(feed.URL))
+			fmt.Printf("%s %d. %s%s\n", ui.ArrowStyle.Render(), i+1, name, label)
+			if name != feed.URL {
+				fmt.Printf("     %s\n", ui.DimStyle.Render(feed.URL))
+			}
+			if result, ok := a.refreshResult(feed.URL); ok {
+				fmt.Printf("     %s %s\n", refreshIcon(result), formatRefreshResult(result))
+			} else {
+				fmt.Printf("     %s\n", ui.DimStyle.Render("not refreshed yet"))
+			}
+		}
+
+		fmt.Println()
+		fmt.Println(ui.ArrowStyle.Render() + "Commands:")
+		fmt.Printf("%s (v)iew    Browse a feed's articles\n", ui.ArrowStyle.Render())
+		fmt.Printf("%s (a)dd     Add new feed\n", ui.ArrowStyle.Render())
+		fmt.Printf("%s (r)emove  Remove feed\n", ui.ArrowStyle.Render())
+		fmt.Printf("%s (g)       Toggle aggregator (estimate dates)\n", ui.ArrowStyle.Render())
+		fmt.Printf("%s (c)ategory Set a feed's category\n", ui.ArrowStyle.Render())
+		fmt.Printf("%s (s)ettings Rename, pause or tune a feed\n", ui.ArrowStyle.Render())
+		fmt.Printf("%s (i)mport  Import feeds from an OPML file\n", ui.ArrowStyle.Render())
+		fmt.Printf("%s (e)xport  Export feeds to an OPML file\n", ui.ArrowStyle.Render())
+		fmt.Printf("%s (b)ack    Return to main menu\n", ui.ArrowStyle.Render())
+		fmt.Printf("%s (h)elp    Show help\n", ui.ArrowStyle.Render())
+		fmt.Println()
+
+		fmt.Print(ui.CommandStyle.Render("→ "))
+		cmd := readLine()
+
+		switch strings.ToLower(cmd) {
+		case "h", "help":
+			a.showFeedsHelp()
+			continue
+		case "v", "view":
+			if len(a.feeds) == 0 {
+				showError("No feeds to view")
+				continue
+			}
+
+			fmt.Println()
+			fmt.Print(ui.CommandStyle.Render("Enter feed number to view: "))
+			input := readLine()
+
+			index, err := strconv.Atoi(input)
+			if err != nil || index < 1 || index > len(a.feeds) {
+				showError("Invalid feed number")
+				continue
+			}
+
+			a.showFeed(a.feeds[index-1].URL)
+			continue
+		case "g", "aggregator":
+			if len(a.feeds) == 0 {
+				showError("No feeds to update")
+				continue
+			}
+
+			fmt.Println()
+			fmt.Print(ui.CommandStyle.Render("Enter feed number to toggle: "))
+			input := readLine()
+
+			index, err := strconv.Atoi(input)
+			if err != nil || index < 1 || index > len(a.feeds) {
+				showError("Invalid feed number")
+				continue
+			}
+
+			a.mu.Lock()
+			a.feeds[index-1].Aggregator = !a.feeds[index-1].Aggregator
+			aggregator := a.feeds[index-1].Aggregator
+			a.mu.Unlock()
+			if err := a.store.SaveFeeds(a.feeds); err != nil {
+				showError("Failed to save feed settings: " + err.Error())
+				continue
+			}
+
+			if aggregator {
+				showSuccess("Marked as aggregator; dates will be estimated on the next refresh")
+			} else {
+				showSuccess("Aggregator setting removed")
+			}
+			continue
+		case "c", "category":
+			if len(a.feeds) == 0 {
+				showError("No feeds to update")
+				continue
+			}
+
+			fmt.Println()
+			fmt.Print(ui.CommandStyle.Render("Enter feed number: "))
+			input := readLine()
+
+			index, err := strconv.Atoi(input)
+			if err != nil || index < 1 || index > len(a.feeds) {
+				showError("Invalid feed number")
+				continue
+			}
+
+			fmt.Print(ui.CommandStyle.Render("Enter category (empty to remove): "))
+			category := normalizeCategory(readLine())
+
+			a.mu.Lock()
+			a.feeds[index-1].Category = category
+			a.mu.Unlock()
+			if err := a.store.SaveFeeds(a.feeds); err != nil {
+				showError("Failed to save feeds: " + err.Error())
+				continue
+			}
+
+			if category == "" {
+				showSuccess("Category removed")
+			} else {
+				showSuccess("Feed moved to " + category)
+			}
+			continue
+		case "a", "add":
+			fmt.Println()
+			fmt.Print(ui.CommandStyle.Render("Enter feed URL: "))
+			feedURL := strings.TrimSpace(readLine())
+
+			// Normalize and validate URL format
+			normalizedURL, err := normalizeURL(feedURL)
+			if err != nil {
+				showError(err.Error())
+				continue
+			}
+
+			// Check if feed already exists
+			if a.hasFeed(normalizedURL) {
+				showError("This feed is already in your list")
+				continue
+			}
+
+			// Show validation progress
+			fmt.Print(ui.DimStyle.Render("Validating feed... "))
+
+			// Validate feed content
+			if err := a.validateFeed(normalizedURL); err != nil {
+				fmt.Println(ui.ErrorStyle.Render("Failed"))
+				showError(err.Error())
+				continue
+			}
+			fmt.Println(ui.SuccessStyle.Render("OK"))
+
+			// Confirm adding the feed
+			if !confirmAction(fmt.Sprintf("Add %s to your list?", normalizedURL)) {
+				fmt.Println(ui.DimStyle.Render("Operation cancelled"))
+				continue
+			}
+
+			fmt.Print(ui.CommandStyle.Render("Category (optional): "))
+			category := normalizeCategory(readLine())
+
+			// Add the feed
+			feed := models.NewFeed(normalizedURL)
+			feed.Category = category
+			if err := a.addFeed(feed); err != nil {
+				showError("Failed to save feeds: " + err.Error())
+				continue
+			}
+
+			fmt.Println(ui.SuccessStyle.Render("Feed added successfully"))
+
+			// Offer to refresh feeds
+			if confirmAction("Would you like to refresh feeds now to fetch articles?") {
+				a.refreshFeeds(false)
+			}
+			continue
+		case "r", "remove":
+			if len(a.feeds) == 0 {
+				showError("No feeds to remove")
+				continue
+			}
+
+			fmt.Println()
+			fmt.Println(ui.ArrowStyle.Render() + "Current feeds:")
+			for i, feed := range a.feeds {
+				fmt.Printf("%s %d. %s\n", ui.ArrowStyle.Render(), i+1, feed.URL)
+			}
+
+			fmt.Println()
+			fmt.Print(ui.CommandStyle.Render("Enter feed number to remove: "))
+			input := readLine()
+
+			index, err := strconv.Atoi(input)
+			if err != nil || index < 1 || index > len(a.feeds) {
+				showError("Invalid feed number")
+				continue
+			}
+
+			feedURL := a.feeds[index-1].URL
+			if !confirmAction(fmt.Sprintf("Are you sure you want to remove '%s'?", feedURL)) {
+				fmt.Println(ui.DimStyle.Render("Operation cancelled"))
+				continue
+			}
+
+			// Remove the feed
+			if err := a.removeFeed(feedURL); err != nil {
+				showError("Failed to save feeds: " + err.Error())
+				continue
+			}
+
+			fmt.Println(ui.SuccessStyle.Render("Feed removed successfully"))
+			continue
+		case "s", "settings":
+			if len(a.feeds) == 0 {
+				showError("No feeds to update")
+				continue
+			}
+
+			fmt.Println()
+			fmt.Print(ui.CommandStyle.Render("Enter feed number: "))
+			input := readLine()
+
+			index, err := strconv.Atoi(input)
+			if err != nil || index < 1 || index > len(a.feeds) {
+				showError("Invalid feed number")
+				continue
+			}
+
+			a.editFeedSettings(index - 1)
+			continue
+		case "i", "import":
+			fmt.Println()
+			fmt.Print(ui.CommandStyle.Render("Enter OPML file path: "))
+			path := strings.TrimSpace(readLine())
+			if path == "" {
+				continue
+			}
+
+			added, skipped, err := a.importOPML(path)
+			if err != nil {
+				showError("Import failed: " + err.Error())
+				continue
+			}
+			showSuccess(fmt.Sprintf("Imported %d feeds (%d duplicates or invalid skipped)", added, skipped))
+
+			if added > 0 && confirmAction("Would you like to refresh feeds now to fetch articles?") {
+				a.refreshFeeds(false)
+			}
+			continue
+		case "e", "export":
+			fmt.Println()
+			fmt.Print(ui.CommandStyle.Render("Enter OPML file path (default feeds.opml): "))
+			path := strings.TrimSpace(readLine())
+			if path == "" {
+				path = "feeds.opml"
+			}
+
+			count, err := a.exportOPML(path)
+			if err != nil {
+				showError("Export failed: " + err.Error())
+				continue
+			}
+			showSuccess(fmt.Sprintf("Exported %d feeds to %s", count, path))
+			continue
+		case "b", "back":
+			return
+		default:
+			showError("Unknown command")
+		}
+	}
+}
+
+// addFeed subscribes to a feed and saves the feed list
+func (a *App) addFeed(feed models.Feed) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.feeds = append(a.feeds, feed)
+	return a.store.SaveFeeds(a.feeds)
+}
+
+// removeFeed unsubscribes from a feed and saves the feed list. Its articles
+// stay in the cache.
+func (a *App) removeFeed(feedURL string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for i, feed := range a.feeds {
+		if feed.URL == feedURL {
+			a.feeds = append(a.feeds[:i], a.feeds[i+1:]...)
+			return a.store.SaveFeeds(a.feeds)
+		}
+	}
+	return fmt.Errorf("not subscribed to %s", feedURL)
+}
+
+// normalizeCategory trims a category name for the category field of
+// feeds.json
+func normalizeCategory(name string) string {
+	return strings.TrimSpace(name)
+}
+
+// hasFeed reports whether a feed with the same canonical URL is subscribed
+func (a *App) hasFeed(feedURL string) bool {
+	canonical := models.CanonicalURL(feedURL)
+	for _, existing := range a.feeds {
+		if models.CanonicalURL(existing.URL) == canonical {
+			return true
+		}
+	}
+	return false
+}
+
+// importOPML adds the feeds of an OPML file that are not subscribed yet.
+// Outline titles become the feeds' display titles.
+func (a *App) importOPML(path string) (added, skipped int, err error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer file.Close()
+
+	subs, err := opml.Read(file)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for _, sub := range subs {
+		feedURL, err := normalizeURL(sub.URL)
+		if err != nil || a.hasFeed(feedURL) {
+			skipped++
+			continue
+		}
+
+		// OPML folders become categories
+		feed := models.NewFeed(feedURL)
+		feed.Title = sub.Title
+		feed.Category = normalizeCategory(sub.Folder)
+		a.feeds = append(a.feeds, feed)
+
+		meta := a.feedMeta[feedURL]
+		meta.URL = feedURL
+		if meta.SiteLink == "" {
+			meta.SiteLink = sub.SiteURL
+		}
+		a.feedMeta[feedURL] = meta
+		added++
+	}
+
+	if added == 0 {
+		return 0, skipped, nil
+	}
+	if err := a.store.SaveFeeds(a.feeds); err != nil {
+		return 0, skipped, err
+	}
+	if err := a.store.SaveFeedMeta(a.feedMeta); err != nil {
+		return added, skipped, err
+	}
+	return added, skipped, nil
+}
+
+// exportOPML writes every subscription to an OPML file
+func (a *App) exportOPML(path string) (int, error) {
+	a.mu.Lock()
+	subs := make([]opml.Subscription, 0, len(a.feeds))
+	for _, feed := range a.feeds {
+		meta := a.feedMeta[feed.URL]
+		title := feed.DisplayTitle(meta)
+		if title == feed.URL {
+			title = ""
+		}
+		subs = append(subs, opml.Subscription{
+			URL:     feed.URL,
+			Title:   title,
+			SiteURL: meta.SiteLink,
+			Folder:  feed.Category,
+		})
+	}
+	a.mu.Unlock()
+
+	file, err := os.Create(path)
+	if err != nil {
+		return 0, err
+	}
+	if err := opml.Write(file, "RSS Reader subscriptions", subs); err != nil {
+		file.Close()
+		return 0, err
+	}
+	if err := file.Close(); err != nil {
+		return 0, err
+	}
+	return len(subs), nil
+}
+
+// editFeedSettings prompts for each setting of a feed; empty input keeps the
+// current value
+func (a *App) editFeedSettings(index int) {
+	a.mu.Lock()
+	feed := a.feeds[index]
+	a.mu.Unlock()
+
+	fmt.Println()
+	fmt.Println(ui.DimStyle.Render("Press Enter to keep the current value"))
+
+	fmt.Print(ui.CommandStyle.Render(fmt.Sprintf("Title [%s] (- to use the feed's own): ", feed.Title)))
+	if input := strings.TrimSpace(readLine()); input == "-" {
+		feed.Title = ""
+	} else if input != "" {
+		feed.Title = input
+	}
+
+	fmt.Print(ui.CommandStyle.Render(fmt.Sprintf("Enabled [%t] (y/n): ", feed.Enabled)))
+	switch strings.ToLower(strings.TrimSpace(readLine())) {
+	case "y", "yes":
+		feed.Enabled = true
+	case "n", "no":
+		feed.Enabled = false
+	}
+
+	fmt.Print(ui.CommandStyle.Render(fmt.Sprintf("Refresh at most every [%s] (e.g. 2h, 0 for every refresh): ", feed.RefreshInterval)))
+	if input := strings.TrimSpace(readLine()); input != "" {
+		interval, err := time.ParseDuration(input)
+		if err != nil || interval < 0 {
+			showError("Invalid interval: " + input)
+			return
+		}
+		feed.RefreshInterval = interval
+	}
+
+	fmt.Print(ui.CommandStyle.Render(fmt.Sprintf("Max articles kept [%d] (0 for the default): ", feed.MaxItems)))
+	if input := strings.TrimSpace(readLine()); input != "" {
+		maxItems, err := strconv.Atoi(input)
+		if err != nil || maxItems < 0 {
+			showError("Invalid number: " + input)
+			return
+		}
+		feed.MaxItems = maxItems
+	}
+
+	fmt.Print(ui.CommandStyle.Render(fmt.Sprintf("Tags [%s] (comma separated, - to clear): ", strings.Join(feed.Tags, ", "))))
+	if input := strings.TrimSpace(readLine()); input == "-" {
+		feed.Tags = nil
+	} else if input != "" {
+		feed.Tags = nil
+		for _, tag := range strings.Split(input, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				feed.Tags = append(feed.Tags, tag)
+			}
+		}
+	}
+
+	a.mu.Lock()
+	a.feeds[index] = feed
+	a.mu.Unlock()
+	if err := a.store.SaveFeeds(a.feeds); err != nil {
+		showError("Failed to save feed settings: " + err.Error())
+		return
+	}
+	showSuccess("Feed settings saved")
+}
+
+// formatFeedSettings summarizes the settings of a feed on one line
+func formatFeedSettings(feed models.Feed) string {
+	var parts []string
+	if !feed.Enabled {
+		parts = append(parts, "paused")
+	}
+	if feed.Category != "" {
+		parts = append(parts, "category "+feed.Category)
+	}
+	if feed.RefreshInterval > 0 {
+		parts = append(parts, "refresh every "+feed.RefreshInterval.String())
+	}
+	if feed.MaxItems > 0 {
+		parts = append(parts, fmt.Sprintf("max %d items", feed.MaxItems))
+	}
+	if feed.Aggregator {
+		parts = append(parts, "aggregator")
+	}
+	if len(feed.Tags) > 0 {
+		parts = append(parts, "tags "+strings.Join(feed.Tags, ", "))
+	}
+	if len(parts) == 0 {
+		return "defaults"
+	}
+	return strings.Join(parts, "; ")
+}
+
+// showFeed shows the details of a single feed and lets the user browse its articles
+func (a *App) showFeed(feedURL string) {
 	for {
+		meta := a.metaFor(feedURL)
+		items := a.feedItems(feedURL)
+		a.mu.Lock()
+		feed := a.feed(feedURL)
+		a.mu.Unlock()
+
+		title := feed.DisplayTitle(meta)
+
 		clearScreen()
-		fmt.Println(ui.HeaderStyle.Render("Manage Feeds"))
+		fmt.Println(ui.HeaderStyle.Render(title))
 		fmt.Println()
-
-		for i, feed := range a.feeds {
-			fmt.Printf("%s %d. %s\n", ui.ArrowStyle.Render(), i+1, feed)
+		fmt.Printf("%s %s\n", ui.DimStyle.Render("Feed:"), ui.LinkStyle.Render(feedURL))
+		if meta.SiteLink != "" {
+			fmt.Printf("%s %s\n", ui.DimStyle.Render("Site:"), ui.LinkStyle.Render(meta.SiteLink))
+		}
+		if !meta.Updated.IsZero() {
+			fmt.Printf("%s %s\n", ui.DimStyle.Render("Last updated:"), ui.DateStyle.Render(meta.Updated.Format("2006-01-02 15:04")))
+		}
+		if !meta.LastFetched.IsZero() {
+			fmt.Printf("%s %s\n", ui.DimStyle.Render("Last fetched:"), ui.DateStyle.Render(meta.LastFetched.Format("2006-01-02 15:04")))
+		}
+		fmt.Printf("%s %d (%d unread)\n", ui.DimStyle.Render("Articles:"), len(items), a.countUnread(items))
+		fmt.Printf("%s %s\n", ui.DimStyle.Render("Settings:"), formatFeedSettings(feed))
+		if result, ok := a.refreshResult(feedURL); ok {
+			fmt.Printf("%s %s %s\n", ui.DimStyle.Render("Status:"), refreshIcon(result), formatRefreshResult(result))
+		}
+		if meta.Description != "" {
+			fmt.Println()
+			fmt.Println(wordWrap(meta.Description, 80))
 		}
 
 		fmt.Println()
 		fmt.Println(ui.ArrowStyle.Render() + "Commands:")
-		fmt.Printf("%s (a)dd     Add new feed\n", ui.ArrowStyle.Render())
-		fmt.Printf("%s (r)emove  Remove feed\n", ui.ArrowStyle.Render())
-		fmt.Printf("%s (b)ack    Return to main menu\n", ui.ArrowStyle.Render())
-		fmt.Printf("%s (h)elp    Show help\n", ui.ArrowStyle.Render())
+		fmt.Printf("%s (l)ist    Browse articles\n", ui.ArrowStyle.Render())
+		fmt.Printf("%s (o)pen    Open site in browser\n", ui.ArrowStyle.Render())
+		fmt.Printf("%s (b)ack    Return to feeds\n", ui.ArrowStyle.Render())
 		fmt.Println()
 
 		fmt.Print(ui.CommandStyle.Render("→ "))
 		cmd := readLine()
 
 		switch strings.ToLower(cmd) {
-		case "h", "help":
-			a.showFeedsHelp()
-			continue
-		case "a", "add":
-			fmt.Println()
-			fmt.Print(ui.CommandStyle.Render("Enter feed URL: "))
-			feedURL := strings.TrimSpace(readLine())
-
-			// Normalize and validate URL format
-			normalizedURL, err := normalizeURL(feedURL)
-			if err != nil {
-				showError(err.Error())
-				continue
-			}
-
-			// Check if feed already exists
-			for _, existingURL := range a.feeds {
-				if existingURL == normalizedURL {
-					showError("This feed is already in your list")
-					continue
-				}
-			}
-
-			// Show validation progress
-			fmt.Print(ui.DimStyle.Render("Validating feed... "))
-
-			// Validate feed content
-			if err := a.validateFeed(normalizedURL); err != nil {
-				fmt.Println(ui.ErrorStyle.Render("Failed"))
-				showError(err.Error())
-				continue
-			}
-			fmt.Println(ui.SuccessStyle.Render("OK"))
-
-			// Confirm adding the feed
-			if !confirmAction(fmt.Sprintf("Add %s to your list?", normalizedURL)) {
-				fmt.Println(ui.DimStyle.Render("Operation cancelled"))
+		case "l", "list", "":
+			if len(items) == 0 {
+				showError("No articles cached for this feed. Try refreshing feeds.")
 				continue
 			}
-
-			// Add the feed
-			a.feeds = append(a.feeds, normalizedURL)
-			if err := a.store.SaveFeeds(a.feeds); err != nil {
-				showError("Failed to save feeds: " + err.Error())
-				continue
-			}
-
-			fmt.Println(ui.SuccessStyle.Render("Feed added successfully"))
-
-			// Offer to refresh feeds
-			if confirmAction("Would you like to refresh feeds now to fetch articles?") {
-				a.refreshFeeds()
-			}
-			continue
-		case "r", "remove":
-			if len(a.feeds) == 0 {
-				showError("No feeds to remove")
-				continue
-			}
-
-			fmt.Println()
-			fmt.Println(ui.ArrowStyle.Render() + "Current feeds:")
-			for i, feed := range a.feeds {
-				fmt.Printf("%s %d. %s\n", ui.ArrowStyle.Render(), i+1, feed)
-			}
-
-			fmt.Println()
-			fmt.Print(ui.CommandStyle.Render("Enter feed number to remove: "))
-			input := readLine()
-
-			index, err := strconv.Atoi(input)
-			if err != nil || index < 1 || index > len(a.feeds) {
-				showError("Invalid feed number")
-				continue
-			}
-
-			feedURL := a.feeds[index-1]
-			if !confirmAction(fmt.Sprintf("Are you sure you want to remove '%s'?", feedURL)) {
-				fmt.Println(ui.DimStyle.Render("Operation cancelled"))
-				continue
+			a.showArticleList(title, items)
+		case "o", "open":
+			link := meta.SiteLink
+			if link == "" {
+				link = feedURL
 			}
-
-			// Remove the feed
-			a.feeds = append(a.feeds[:index-1], a.feeds[index:]...)
-			if err := a.store.SaveFeeds(a.feeds); err != nil {
-				showError("Failed to save feeds: " + err.Error())
-				continue
+			if err := openInBrowser(link); err != nil {
+				showError("Failed to open browser")
+			} else {
+				showSuccess("Opened in browser")
 			}
-
-			fmt.Println(ui.SuccessStyle.Render("Feed removed successfully"))
-			continue
 		case "b", "back":
 			return
 		default:
@@ -947,19 +1907,60 @@ func (a *App) manageFeeds() {
 	}
 }
 
+// feedItems returns the cached articles of a feed, newest first
+func (a *App) feedItems(feedURL string) []models.FeedItem {
+	a.mu.Lock()
+	cached := a.cached
+	// Items cached before the feed URL was recorded are matched by feed title
+	title := a.feedMeta[feedURL].Title
+	a.mu.Unlock()
+
+	var items []models.FeedItem
+	for _, item := range cached {
+		if item.FeedURL == feedURL || (item.FeedURL == "" && title != "" && item.FeedSource == title) {
+			items = append(items, item)
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].Published.After(items[j].Published) // Newer first
+	})
+	return items
+}
+
 func (a *App) displayArticle(item models.FeedItem) bool {
+	a.setRead(item, true)
+
 	for {
 		clearScreen()
 		fmt.Println(ui.TitleStyle.Render(item.Title))
 		fmt.Printf("%s %s\n",
 			ui.DimStyle.Render("Source:"),
-			ui.SourceStyle.Render(item.FeedSource))
+			ui.SourceStyle.Render(item.SourceLabel()))
 		fmt.Printf("%s %s\n",
 			ui.DimStyle.Render("Published:"),
-			ui.DateStyle.Render(item.Published.Format("2006-01-02")))
+			a.formatItemDate(item))
+		if item.DateEstimated {
+			fmt.Println(ui.DimStyle.Render("  (date estimated from when the article was first seen)"))
+		}
+		if item.Author != "" {
+			fmt.Printf("%s %s\n", ui.DimStyle.Render("Author:"), item.Author)
+		}
+		if len(item.Categories) > 0 {
+			fmt.Printf("%s %s\n", ui.DimStyle.Render("Categories:"), strings.Join(item.Categories, ", "))
+		}
 		fmt.Println()
-		fmt.Println(wordWrap(item.Description, 80))
+		body := item.Description
+		if body == "" {
+			body = item.Content
+		}
+		fmt.Println(wordWrap(body, 80))
 		fmt.Println()
+		for _, enclosure := range item.Enclosures {
+			fmt.Printf("%s %s %s\n",
+				ui.DimStyle.Render("Attachment:"),
+				ui.LinkStyle.Render(enclosure.URL),
+				ui.DimStyle.Render(enclosure.Type))
+		}
 		fmt.Printf("%s %s\n",
 			ui.DimStyle.Render("Link:"),
 			ui.LinkStyle.Render(item.Link))
@@ -967,27 +1968,25 @@ func (a *App) displayArticle(item models.FeedItem) bool {
 
 		// Show commands with enhanced styling
 		fmt.Println(ui.SectionStyle.Render("Commands:"))
-		fmt.Printf("%s %s Mark as interesting and continue\n",
-			ui.KeyStyle.Render("(y)es"),
-			ui.DimStyle.Render("→"))
-		fmt.Printf("%s (n)o      Skip to next article\n", ui.ArrowStyle.Render())
-		fmt.Printf("%s (b)ack    Return to results\n", ui.ArrowStyle.Render())
-		fmt.Printf("%s (o)pen    Open in browser\n", ui.ArrowStyle.Render())
-		fmt.Printf("%s (h)elp    Show help\n", ui.ArrowStyle.Render())
+		a.printBindings(ui.ScreenArticle)
 		fmt.Println()
 
 		// Show tips
 		fmt.Println(ui.DimStyle.Render("Tips:"))
 		fmt.Printf("%s Marking articles as interesting improves recommendations\n", ui.ArrowStyle.Render())
-		fmt.Printf("%s Use 'o' to read full article in your browser\n", ui.ArrowStyle.Render())
+		fmt.Printf("%s Use '%s' to read full article in your browser\n", ui.ArrowStyle.Render(), a.keys.Key(ui.ActionOpenArticle))
 		fmt.Println()
 
 		// Read and handle command
 		fmt.Print(ui.CommandStyle.Render("→ "))
-		cmd := strings.ToLower(readLine())
+		action, ok := a.keys.Lookup(ui.ScreenArticle, readLine())
+		if !ok {
+			showError("Unknown command")
+			continue
+		}
 
-		switch cmd {
-		case "y", "yes":
+		switch action {
+		case ui.ActionInteresting:
 			// Update user profile with interests from this article
 			a.profile.UpdateInterests(item.Title + " " + item.Description)
 			if err := a.store.SaveProfile(a.profile); err != nil {
@@ -996,22 +1995,22 @@ func (a *App) displayArticle(item models.FeedItem) bool {
 				showSuccess("Article marked as interesting")
 			}
 			return true
-		case "n", "no":
+		case ui.ActionSkip:
 			return true
-		case "b", "back":
+		case ui.ActionBack:
 			return false
-		case "o", "open":
-			if err := openInBrowser(item.Link); err != nil {
+		case ui.ActionOpenArticle:
+			if err := a.openArticle(item); err != nil {
 				showError("Failed to open browser")
 			} else {
 				showSuccess("Opened in browser")
 			}
 			continue
-		case "h", "help":
-			a.showArticleHelp()
+		case ui.ActionToggleRead:
+			a.toggleRead(item)
 			continue
-		default:
-			showError("Unknown command")
+		case ui.ActionHelp:
+			a.showArticleHelp()
 			continue
 		}
 	}
@@ -1044,8 +2043,14 @@ func (a *App) showFeedsHelp() {
 	fmt.Println()
 	fmt.Println(ui.ArrowStyle.Render() + "Available Commands:")
 	fmt.Println()
+	fmt.Printf("%s view (v)          Show feed details and browse its articles\n", ui.ArrowStyle.Render())
 	fmt.Printf("%s add (a)           Add a new RSS feed\n", ui.ArrowStyle.Render())
 	fmt.Printf("%s remove (r)        Remove an existing feed\n", ui.ArrowStyle.Render())
+	fmt.Printf("%s aggregator (g)    Toggle whether a feed's dates are estimated\n", ui.ArrowStyle.Render())
+	fmt.Printf("%s category (c)      Put a feed in a category such as Go or News\n", ui.ArrowStyle.Render())
+	fmt.Printf("%s settings (s)      Set a feed's title, pause it, or refresh it less often\n", ui.ArrowStyle.Render())
+	fmt.Printf("%s import (i)        Add the feeds listed in an OPML file\n", ui.ArrowStyle.Render())
+	fmt.Printf("%s export (e)        Write your feeds to an OPML file\n", ui.ArrowStyle.Render())
 	fmt.Printf("%s back (b)          Return to main menu\n", ui.ArrowStyle.Render())
 	fmt.Printf("%s help (h)          Show this help message\n", ui.ArrowStyle.Render())
 	fmt.Println()
@@ -1059,6 +2064,109 @@ func (a *App) showFeedsHelp() {
 	return
 }
 
+// readMarker renders the unread indicator shown next to list items
+func (a *App) readMarker(item models.FeedItem) string {
+	if !a.config.Display.ShowReadStatus || a.profile.IsRead(item) {
+		return ui.ReadStyle.Render()
+	}
+	return ui.UnreadStyle.Render()
+}
+
+func (a *App) filterUnread(items []models.FeedItem) []models.FeedItem {
+	var unread []models.FeedItem
+	for _, item := range items {
+		if !a.profile.IsRead(item) {
+			unread = append(unread, item)
+		}
+	}
+	return unread
+}
+
+func (a *App) countUnread(items []models.FeedItem) int {
+	unread := 0
+	for _, item := range items {
+		if !a.profile.IsRead(item) {
+			unread++
+		}
+	}
+	return unread
+}
+
+// setRead updates the read state of an article and saves the profile if it changed
+func (a *App) setRead(item models.FeedItem, read bool) {
+	if a.profile.IsRead(item) == read {
+		return
+	}
+	if read {
+		a.profile.MarkRead(item)
+	} else {
+		a.profile.MarkUnread(item)
+	}
+	if err := a.store.SaveProfile(a.profile); err != nil {
+		showError("Failed to save profile: " + err.Error())
+	}
+}
+
+func (a *App) toggleRead(item models.FeedItem) {
+	read := a.profile.ToggleRead(item)
+	if err := a.store.SaveProfile(a.profile); err != nil {
+		showError("Failed to save profile: " + err.Error())
+		return
+	}
+	if read {
+		showSuccess("Marked as read")
+	} else {
+		showSuccess("Marked as unread")
+	}
+}
+
+func (a *App) markAllRead(items []models.FeedItem) {
+	unread := a.countUnread(items)
+	if unread == 0 {
+		showError("No unread articles")
+		return
+	}
+	if !confirmAction(fmt.Sprintf("Mark %d articles as read?", unread)) {
+		fmt.Println(ui.DimStyle.Render("Operation cancelled"))
+		return
+	}
+	for _, item := range items {
+		a.profile.MarkRead(item)
+	}
+	if err := a.store.SaveProfile(a.profile); err != nil {
+		showError("Failed to save profile: " + err.Error())
+		return
+	}
+	showSuccess(fmt.Sprintf("Marked %d articles as read", unread))
+}
+
+// openArticle opens an article in the browser and marks it as read
+func (a *App) openArticle(item models.FeedItem) error {
+	if err := openInBrowser(item.Link); err != nil {
+		return err
+	}
+	a.setRead(item, true)
+	return nil
+}
+
+// formatItemDate renders an item's date, prefixing estimated dates with "~"
+func (a *App) formatItemDate(item models.FeedItem) string {
+	date := item.Published.Format(a.config.Display.DateFormat)
+	if item.DateEstimated {
+		date = "~" + date
+	}
+	return ui.DateStyle.Render(date)
+}
+
+// articleItems extracts the feed items from scored articles
+func articleItems(articles []models.ArticleScore) []models.FeedItem {
+	items := make([]models.FeedItem, len(articles))
+	for i, article := range articles {
+		items[i] = article.Item
+	}
+	return items
+}
+
 // Helper functions
 func clearScreen() {
 	fmt.Print("\033[H\033[2J")
@@ -1228,79 +2336,78 @@ func min(a, b int) int {
 	return b
 }
 
-func parseFeed(url string) []models.FeedItem {
-	fp := gofeed.NewParser()
-	feed, err := fp.ParseURL(url)
-	if err != nil {
-		return nil
-	}
-
+// parseFeed converts a fetched feed into feed items. Items without a date,
+// and every item of an aggregator feed (which stamps all items with the same
+// time), get an estimated date based on when they were first seen.
+func parseFeed(settings models.Feed, feed *gofeed.Feed) []models.FeedItem {
 	var items []models.FeedItem
+	fetchedAt := time.Now()
 
-	// Special handling for feeds known to use same timestamp for all items
-	isAggregatorFeed := strings.Contains(url, "lessnews.dev") ||
-		strings.Contains(feed.Title, "aggregator") ||
-		strings.Contains(feed.Title, "digest")
-
-	// Use a base time for progressive offsets if needed
-	baseTime := time.Now()
-	if len(feed.Items) > 0 && feed.Items[0].PublishedParsed != nil {
-		baseTime = *feed.Items[0].PublishedParsed
-	}
-
-	// Process items in reverse for aggregator feeds (newest at bottom)
-	processItems := feed.Items
-	if isAggregatorFeed {
-		// Reverse the items so oldest appear first
-		for i, j := 0, len(processItems)-1; i < j; i, j = i+1, j-1 {
-			processItems[i], processItems[j] = processItems[j], processItems[i]
-		}
+	source := settings.Title
+	if source == "" {
+		source = feed.Title
 	}
 
-	for i, item := range processItems {
-		// Parse the published date
-		published := time.Now() // default to current time
+	for i, item := range feed.Items {
+		var published time.Time
+		dated := false
 
 		// Try to use parsed dates from the feed first
 		if item.PublishedParsed != nil {
-			published = *item.PublishedParsed
+			published, dated = *item.PublishedParsed, true
 		} else if item.UpdatedParsed != nil {
-			published = *item.UpdatedParsed
+			published, dated = *item.UpdatedParsed, true
 		} else if item.Published != "" {
 			if t, err := parseDate(item.Published); err == nil {
-				published = t
+				published, dated = t, true
 			}
 		} else if item.Updated != "" {
 			if t, err := parseDate(item.Updated); err == nil {
-				published = t
+				published, dated = t, true
 			}
 		}
 
-		// For aggregator feeds or when dates are missing, create progressive timestamps
-		if isAggregatorFeed || (item.Published == "" && item.Updated == "") {
-			// Create a progressive offset - newer items will have more recent timestamps
-			// Each item is 2-6 hours apart to ensure visible differences
-			hourOffset := time.Duration((len(processItems)-i)*(2+rand.Intn(4))) * time.Hour
-			// Add some minute/second variation
-			minuteOffset := time.Duration(rand.Intn(60)) * time.Minute
-			secondOffset := time.Duration(rand.Intn(60)) * time.Second
+		// Estimate from the fetch time, one second apart so items fetched
+		// together keep the order of the feed (newest first)
+		estimated := settings.Aggregator || !dated
+		if estimated {
+			published = fetchedAt.Add(-time.Duration(i) * time.Second)
+		}
 
-			if isAggregatorFeed {
-				// For aggregator feeds, always use the progressive offset
-				published = baseTime.Add(-hourOffset).Add(-minuteOffset).Add(-secondOffset)
-			} else {
-				// For other feeds, only use random offset if we couldn't get a date
-				randomOffset := time.Duration(rand.Intn(24)) * time.Hour
-				published = published.Add(-randomOffset)
+		var author string
+		if item.Author != nil {
+			author = item.Author.Name
+		} else if len(item.Authors) > 0 && item.Authors[0] != nil {
+			author = item.Authors[0].Name
+		}
+
+		var enclosures []models.Enclosure
+		for _, enclosure := range item.Enclosures {
+			if enclosure == nil || enclosure.URL == "" {
+				continue
 			}
+			enclosures = append(enclosures, models.Enclosure{
+				URL:    enclosure.URL,
+				Type:   enclosure.Type,
+				Length: enclosure.Length,
+			})
 		}
 
 		items = append(items, models.FeedItem{
+			ID:          models.NewItemID(settings.URL, item.GUID, item.Link, item.Title),
 			Title:       item.Title,
 			Description: item.Description,
+			Content:     item.Content,
 			Link:        item.Link,
+			Author:      author,
+			Categories:  item.Categories,
+			Enclosures:  enclosures,
 			Published:   published,
-			FeedSource:  feed.Title,
+			FeedSource:  source,
+			FeedURL:     settings.URL,
+
+			DateEstimated: estimated,
+			FirstSeen:     fetchedAt,
 		})
 	}
 	return items
@@ -1312,10 +2419,12 @@ func (a *App) showMainHelp() {
 	fmt.Println()
 	fmt.Println(ui.ArrowStyle.Render() + "Available Commands:")
 	fmt.Println()
+	fmt.Printf("%s latest (l)       Browse all articles, newest first\n", ui.ArrowStyle.Render())
 	fmt.Printf("%s search (s)       Search through all articles\n", ui.ArrowStyle.Render())
 	fmt.Printf("%s recommended (r)   View articles based on your interests\n", ui.ArrowStyle.Render())
 	fmt.Printf("%s interests (i)     Add or remove topics you're interested in\n", ui.ArrowStyle.Render())
 	fmt.Printf("%s feeds (f)         Manage your RSS feed subscriptions\n", ui.ArrowStyle.Render())
+	fmt.Printf("%s category (c)      Limit articles and recommendations to one category\n", ui.ArrowStyle.Render())
 	fmt.Printf("%s refresh (x)       Update all feeds to get latest articles\n", ui.ArrowStyle.Render())
 	fmt.Printf("%s quit (q)          Exit the application\n", ui.ArrowStyle.Render())
 	fmt.Printf("%s help (h)          Show this help message\n", ui.ArrowStyle.Render())
@@ -1329,16 +2438,13 @@ func (a *App) showMainHelp() {
 	readLine()
 }
 
-func (a *App) showSearchHelp() {
+func (a *App) showListHelp() {
 	clearScreen()
-	fmt.Println(ui.HeaderStyle.Render("Help - Search Results"))
+	fmt.Println(ui.HeaderStyle.Render("Help - Article List"))
 	fmt.Println()
 	fmt.Println(ui.ArrowStyle.Render() + "Available Commands:")
 	fmt.Println()
-	fmt.Printf("%s next (n)          Go to next page\n", ui.ArrowStyle.Render())
-	fmt.Printf("%s prev (p)          Go to previous page\n", ui.ArrowStyle.Render())
-	fmt.Printf("%s view (v)          View article details\n", ui.ArrowStyle.Render())
-	fmt.Printf("%s back (b)          Return to main menu\n", ui.ArrowStyle.Render())
+	a.printBindings(ui.ScreenList)
 	fmt.Println()
 	fmt.Println(ui.DimStyle.Render("Tips:"))
 	fmt.Printf("%s Use single-letter commands for faster navigation\n", ui.ArrowStyle.Render())
@@ -1355,21 +2461,68 @@ func (a *App) showArticleHelp() bool {
 	fmt.Println()
 	fmt.Println(ui.ArrowStyle.Render() + "Available Commands:")
 	fmt.Println()
-	fmt.Printf("%s yes (y)           Mark as interesting and continue\n", ui.ArrowStyle.Render())
-	fmt.Printf("%s no (n)            Skip to next article\n", ui.ArrowStyle.Render())
-	fmt.Printf("%s back (b)          Return to main menu\n", ui.ArrowStyle.Render())
-	fmt.Printf("%s open (o)          Open in browser\n", ui.ArrowStyle.Render())
-	fmt.Printf("%s help (h)          Show this help message\n", ui.ArrowStyle.Render())
+	a.printBindings(ui.ScreenArticle)
 	fmt.Println()
 	fmt.Println(ui.DimStyle.Render("Tips:"))
 	fmt.Printf("%s Marking articles as interesting improves recommendations\n", ui.ArrowStyle.Render())
-	fmt.Printf("%s Use 'o' to read full article in your browser\n", ui.ArrowStyle.Render())
+	fmt.Printf("%s Use '%s' to read full article in your browser\n", ui.ArrowStyle.Render(), a.keys.Key(ui.ActionOpenArticle))
 	fmt.Println()
 	fmt.Println(ui.DimStyle.Render("Press Enter to return..."))
 	readLine()
 	return true
 }
 
+// printBindings lists the commands of a screen using the configured keys
+func (a *App) printBindings(screen ui.Screen) {
+	if screen != ui.ScreenArticle {
+		fmt.Printf("%s %-12s Navigate items\n", ui.ArrowStyle.Render(), "↑/↓")
+		fmt.Printf("%s %-12s Change pages\n", ui.ArrowStyle.Render(), "←/→")
+		fmt.Printf("%s %-12s View selected article\n", ui.ArrowStyle.Render(), "Enter")
+	}
+	for _, binding := range a.keys.Bindings(screen) {
+		key := binding.Key
+		if binding.Action == ui.ActionOpenArticle && screen != ui.ScreenArticle {
+			key += "[number]"
+		}
+		fmt.Printf("%s %-12s %s\n", ui.ArrowStyle.Render(), key, binding.Description)
+	}
+}
+
+// readArticleNumber echoes the open key and reads the article number typed
+// after it, finishing on Enter or any other non-digit key
+func readArticleNumber(prefix string) (int, bool) {
+	var numStr string
+	fmt.Print(prefix) // Show the key being typed
+
+	// Read subsequent digits
+	for {
+		k, err := readKey()
+		if err != nil {
+			break
+		}
+		// If Enter is pressed or non-digit/non-backspace, break
+		if k.key == 13 || (k.key != 127 && (k.key < '0' || k.key > '9')) {
+			break
+		}
+		// If backspace, remove last digit
+		if k.key == 127 && len(numStr) > 0 {
+			numStr = numStr[:len(numStr)-1]
+			fmt.Print("\b \b") // Erase character
+			continue
+		}
+		// Add digit and show it
+		numStr += string(k.char)
+		fmt.Print(string(k.char))
+	}
+	fmt.Println() // New line after input
+
+	num, err := strconv.Atoi(numStr)
+	if err != nil {
+		return 0, false
+	}
+	return num, true
+}
+
 // Add this helper function for confirmations
 func confirmAction(prompt string) bool {
 	fmt.Println()
@@ -1460,6 +2613,102 @@ func parseDate(dateStr string) (time.Time, error) {
 	return time.Now(), fmt.Errorf("could not parse date: %s", dateStr)
 }
 
-func (a *App) exportToSheets(articles []models.ArticleScore) storage.ExportResult {
-	return a.store.ExportToSheets(articles, "")
+// exporters lists the export destinations offered by the export picker
+func (a *App) exporters() []export.Exporter {
+	dir := a.store.ExportDir()
+	sheets := storage.SheetsExportOptions{
+		Folder:      a.config.Sheets.Folder,
+		DatedTabs:   a.config.Sheets.DatedTabs,
+		OpenAuthURL: openSignIn,
+	}
+	appendSheets := sheets
+	appendSheets.Append = true
+
+	return []export.Exporter{
+		a.store.SheetsExporter(sheets),
+		a.store.SheetsExporter(appendSheets),
+		export.NewCSVExporter(dir),
+		export.NewMarkdownExporter(dir),
+		export.NewHTMLExporter(dir),
+	}
+}
+
+// openSignIn opens the Google sign-in page and prints its URL in case no
+// browser can be started
+func openSignIn(authURL string) {
+	fmt.Println()
+	fmt.Println(ui.StatusStyle.Render("Sign in to Google in your browser to allow Sheets exports."))
+	fmt.Println(ui.DimStyle.Render("If the page does not open, visit:"))
+	fmt.Println(ui.LinkStyle.Render(authURL))
+	openInBrowser(authURL)
+}
+
+// exportArticles asks for an export destination, exports the articles and
+// offers to open the result
+func (a *App) exportArticles(articles []models.ArticleScore) {
+	exporters := a.exporters()
+
+	clearScreen()
+	fmt.Println(ui.HeaderStyle.Render("Export Recommendations"))
+	fmt.Println()
+	for i, exporter := range exporters {
+		fmt.Printf("%s %d. %s\n", ui.ArrowStyle.Render(), i+1, exporter.Name())
+	}
+	fmt.Println()
+	fmt.Print(ui.CommandStyle.Render("Enter export number (Enter to cancel): "))
+
+	input := strings.TrimSpace(readLine())
+	if input == "" {
+		return
+	}
+	index, err := strconv.Atoi(input)
+	if err != nil || index < 1 || index > len(exporters) {
+		showError("Invalid export number")
+		return
+	}
+	exporter := exporters[index-1]
+
+	stop := showProgress("Exporting to " + exporter.Name())
+	result, err := exporter.Export(articles)
+	stop()
+
+	if err != nil {
+		clearScreen()
+		fmt.Println(ui.HeaderStyle.Render("Export Error"))
+		fmt.Println()
+		fmt.Println(ui.ErrorStyle.Render(err.Error()))
+		fmt.Println()
+		fmt.Print(ui.CommandStyle.Render("Press Enter to continue..."))
+		readLine()
+		return
+	}
+
+	clearScreen()
+	fmt.Println(ui.HeaderStyle.Render("Export Success"))
+	fmt.Println()
+	fmt.Println(ui.SuccessStyle.Render(fmt.Sprintf("Exported %d articles to %s", result.Count, exporter.Name())))
+	if result.Skipped > 0 {
+		fmt.Println(ui.DimStyle.Render(fmt.Sprintf("Skipped %d articles that were already exported", result.Skipped)))
+	}
+	fmt.Println()
+	fmt.Println(ui.DimStyle.Render("Location:"))
+	fmt.Printf("\033]8;;%s\033\\%s\033]8;;\033\\\n", result.URL, ui.LinkStyle.Render(result.Location))
+	fmt.Println()
+	fmt.Println(ui.DimStyle.Render("Commands:"))
+	fmt.Printf("%s %-9s Open in browser\n", ui.ArrowStyle.Render(), a.keys.Key(ui.ActionOpenArticle))
+	fmt.Printf("%s %-9s Return to recommendations\n", ui.ArrowStyle.Render(), a.keys.Key(ui.ActionBack))
+	fmt.Println()
+
+	key, err := readKey()
+	if err != nil {
+		return
+	}
+
+	if string(key.char) == a.keys.Key(ui.ActionOpenArticle) {
+		if err := openInBrowser(result.URL); err != nil {
+			showError("Failed to open browser")
+		} else {
+			showSuccess("Opened export in browser")
+		}
+	}
 }
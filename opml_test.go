@@ -0,0 +1,59 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/thedittmer/rss-reader/internal/models"
+	"github.com/thedittmer/rss-reader/internal/storage"
+)
+
+func TestImportOPMLSkipsDuplicates(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	store, err := storage.NewStorage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := &App{
+		store:    store,
+		feeds:    []models.Feed{models.NewFeed("https://go.example/feed")},
+		feedMeta: make(map[string]models.FeedMeta),
+	}
+
+	const doc = `<?xml version="1.0"?>
+<opml version="2.0"><body>
+  <outline text="Tech">
+    <outline text="Go" xmlUrl="http://www.go.example/feed/"/>
+    <outline text="Rust" xmlUrl="https://rust.example/feed" htmlUrl="https://rust.example/"/>
+  </outline>
+  <outline text="Rust again" xmlUrl="https://rust.example/feed"/>
+  <outline text="Broken" xmlUrl="ftp://files.example/feed"/>
+</body></opml>`
+	path := filepath.Join(t.TempDir(), "feeds.opml")
+	if err := os.WriteFile(path, []byte(doc), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	added, skipped, err := a.importOPML(path)
+	if err != nil {
+		t.Fatalf("importOPML: %v", err)
+	}
+	if added != 1 || skipped != 3 {
+		t.Errorf("added %d, skipped %d; want 1, 3", added, skipped)
+	}
+	if len(a.feeds) != 2 {
+		t.Fatalf("got %d feeds, want 2", len(a.feeds))
+	}
+	if feed := a.feeds[1]; feed.URL != "https://rust.example/feed" || feed.Title != "Rust" || feed.Category != "Tech" {
+		t.Errorf("imported feed = %+v, want Rust in Tech", feed)
+	}
+	if meta := a.feedMeta["https://rust.example/feed"]; meta.SiteLink != "https://rust.example/" {
+		t.Errorf("site link = %q, want the outline's htmlUrl", meta.SiteLink)
+	}
+
+	// Importing the same file again adds nothing
+	if added, _, err := a.importOPML(path); err != nil || added != 0 {
+		t.Errorf("second import added %d (error %v), want 0", added, err)
+	}
+}